@@ -2,12 +2,15 @@ package main
 
 import (
 	"barber-backend-api/internal/config"
+	"barber-backend-api/internal/events"
 	"barber-backend-api/internal/logging"
 	"barber-backend-api/internal/models"
 	"barber-backend-api/repository"
 	"barber-backend-api/service"
 	"barber-backend-api/transport"
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -21,25 +24,77 @@ func main() {
 
 	logger := logging.InitLogger()
 	
+	loc := config.LoadLocation(logger)
+	slotStep := config.LoadSlotStep(logger)
+	cancellationPolicy := config.LoadCancellationPolicy(logger)
+	noShowRule := config.LoadNoShowRule(logger)
+	reviewFilter := config.LoadReviewFilter(logger)
+	ratingPrior := config.LoadRatingPrior(logger)
+
 	db := config.SetupDataBase(logger)
 
-	if err := db.AutoMigrate(&models.Appointments{}, &models.Barber{}, &models.Client{}); err != nil {
+	if err := config.MigrateAppointmentTimes(db, logger, loc); err != nil {
+		logger.Error("ошибка переноса времени записей", "error", err)
+		panic(fmt.Sprintf("не удалось перенести время записей: %v", err))
+	}
+
+	if err := config.MigrateAppointmentStatuses(db, logger); err != nil {
+		logger.Error("ошибка заполнения статусов записей", "error", err)
+		panic(fmt.Sprintf("не удалось заполнить статусы записей: %v", err))
+	}
+
+	if err := db.AutoMigrate(&models.Appointments{}, &models.Barber{}, &models.Client{}, &models.BarberSchedule{}, &models.ScheduleInterval{}, &models.TimeOff{}, &models.Holiday{}, &models.Service{}, &models.BarberServiceLink{}, &models.AppointmentStatusChange{}, &models.AppointmentReschedule{}, &models.SlotHold{}, &models.WaitlistEntry{}, &models.AppointmentSeries{}, &models.WalkInEntry{}, &models.Review{}, &models.BarberRatingStats{}); err != nil {
 		logger.Error("ошибка при выполнении автомиграции", "error", err)
 		panic(fmt.Sprintf("не удалось выполнить миграции: %v", err))
 	}
+
+	if err := config.MigrateAppointmentRatings(db, logger); err != nil {
+		logger.Error("ошибка переноса оценок в отзывы", "error", err)
+		panic(fmt.Sprintf("не удалось перенести оценки в отзывы: %v", err))
+	}
+
+	if err := config.MigrateRatingStats(db, logger); err != nil {
+		logger.Error("ошибка заполнения агрегатов рейтинга", "error", err)
+		panic(fmt.Sprintf("не удалось заполнить агрегаты рейтинга: %v", err))
+	}
 	
 
 	appointmentsRepo := repository.NewAppointmentsRepository(db)
 	clientsRepo := repository.NewClientsRepository(db)
 	barberRepo := repository.NewBarbersRepository(logger, db)
+	schedulesRepo := repository.NewSchedulesRepository(db)
+	timeOffRepo := repository.NewTimeOffRepository(db)
+	holidaysRepo := repository.NewHolidaysRepository(db)
+	servicesRepo := repository.NewServicesRepository(db)
+	barberServicesRepo := repository.NewBarberServicesRepository(db)
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	walkInRepo := repository.NewWalkInRepository(db)
+	reviewsRepo := repository.NewReviewsRepository(db)
+
+	calendar := service.NewWorkCalendar(loc, slotStep, schedulesRepo, timeOffRepo, holidaysRepo)
+	bus := events.NewBus()
+
+	waitlistService := service.NewWaitlistService(logger, waitlistRepo, appointmentsRepo, barberRepo, clientsRepo, barberServicesRepo, calendar, bus)
+	appointmentsService := service.NewAppointmentsService(appointmentsRepo, barberRepo, barberServicesRepo, clientsRepo, calendar, cancellationPolicy, noShowRule, waitlistService, bus)
+	clientsService := service.NewClientsService( clientsRepo, appointmentsRepo, noShowRule)
+	barberService := service.NewBarbersService( logger, barberRepo, barberServicesRepo)
+	schedulesService := service.NewBarberScheduleService(logger, schedulesRepo, barberRepo)
+	timeOffService := service.NewTimeOffService(logger, loc, timeOffRepo, barberRepo, appointmentsRepo)
+	holidayService := service.NewHolidayService(logger, loc, holidaysRepo)
+	availabilityService := service.NewAvailabilityService(appointmentsRepo, barberRepo, barberServicesRepo, calendar)
+	catalogService := service.NewCatalogService(servicesRepo)
+	barberMenuService := service.NewBarberMenuService(logger, barberServicesRepo, barberRepo, servicesRepo)
+	holdService := service.NewHoldService(logger, appointmentsRepo, barberRepo, clientsRepo, barberServicesRepo, calendar, waitlistService, bus)
+	walkInService := service.NewWalkInService(logger, walkInRepo, appointmentsRepo, barberRepo, clientsRepo, barberServicesRepo, calendar, bus)
+	reviewsService := service.NewReviewsService(logger, reviewsRepo, appointmentsRepo, barberRepo, reviewFilter, ratingPrior, bus)
+	barberProfileService := service.NewBarberProfileService(barberRepo, barberServicesRepo, schedulesRepo, reviewsRepo, appointmentsRepo, calendar, ratingPrior)
 
-	appointmentsService := service.NewAppointmentsService(appointmentsRepo, barberRepo)
-	clientsService := service.NewClientsService( clientsRepo)
-	barberService := service.NewBarbersService( logger, barberRepo)
+	// Истёкшие брони снимаются раз в минуту; до этого они уже не мешают записи, так как проверяется expires_at.
+	go holdService.RunSweeper(context.Background(), time.Minute)
 
 	r := gin.Default()
 
-	transport.RegisterRoutes(r, appointmentsService, barberService, clientsService, logger)
+	transport.RegisterRoutes(r, appointmentsService, barberService, clientsService, schedulesService, timeOffService, holidayService, availabilityService, catalogService, barberMenuService, waitlistService, holdService, walkInService, reviewsService, barberProfileService, bus, logger)
 
 	if err := r.Run(); err != nil {
 		panic(fmt.Sprintf("ошибка запуска сервера: %v", err))
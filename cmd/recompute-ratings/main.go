@@ -0,0 +1,36 @@
+// Команда recompute-ratings пересобирает агрегаты рейтинга всех парикмахеров по опубликованным отзывам.
+// Нужна, если агрегаты разошлись с отзывами, например после ручной правки базы.
+package main
+
+import (
+	"barber-backend-api/internal/config"
+	"barber-backend-api/internal/logging"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	if err := godotenv.Load(".env"); err != nil && !os.IsNotExist(err) {
+		panic(err)
+	}
+
+	logger := logging.InitLogger()
+	db := config.SetupDataBase(logger)
+
+	if err := db.AutoMigrate(&models.BarberRatingStats{}); err != nil {
+		logger.Error("ошибка при выполнении автомиграции", "error", err)
+		panic(fmt.Sprintf("не удалось выполнить миграции: %v", err))
+	}
+
+	rated, err := repository.NewReviewsRepository(db).RecomputeRatings()
+	if err != nil {
+		logger.Error("ошибка пересчёта рейтингов", "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("рейтинги пересчитаны", "barbers_with_reviews", rated)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"barber-backend-api/internal/models"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultCancellationWindows = "24h:50"
+	defaultNoShowFeePercent    = 100
+)
+
+// LoadCancellationPolicy читает правила отмены из переменных окружения:
+// CANCELLATION_WINDOWS — список "срок:процент" через запятую, например "24h:50,2h:100";
+// NO_SHOW_FEE_PERCENT — штраф за неявку в процентах от цены.
+func LoadCancellationPolicy(logger *slog.Logger) models.CancellationPolicy {
+	raw := os.Getenv("CANCELLATION_WINDOWS")
+	if raw == "" {
+		raw = defaultCancellationWindows
+	}
+
+	windows, err := parseCancellationWindows(raw)
+	if err != nil {
+		logger.Error("некорректный CANCELLATION_WINDOWS", "value", raw, "error", err)
+		panic(err)
+	}
+
+	noShow := defaultNoShowFeePercent
+	if value := os.Getenv("NO_SHOW_FEE_PERCENT"); value != "" {
+		noShow, err = strconv.Atoi(value)
+		if err != nil || noShow < 0 || noShow > 100 {
+			logger.Error("некорректный NO_SHOW_FEE_PERCENT, нужен процент от 0 до 100", "value", value)
+			panic("NO_SHOW_FEE_PERCENT должен быть от 0 до 100")
+		}
+	}
+
+	logger.Info("правила отмены записей", "windows", raw, "no_show_fee_percent", noShow)
+	return models.CancellationPolicy{Windows: windows, NoShowFeePercent: noShow}
+}
+
+func parseCancellationWindows(raw string) ([]models.CancellationWindow, error) {
+	var windows []models.CancellationWindow
+	for _, part := range strings.Split(raw, ",") {
+		before, percent, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("окно %q должно быть в формате срок:процент", part)
+		}
+
+		d, err := time.ParseDuration(before)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("некорректный срок в окне %q", part)
+		}
+		fee, err := strconv.Atoi(percent)
+		if err != nil || fee < 0 || fee > 100 {
+			return nil, fmt.Errorf("процент в окне %q должен быть от 0 до 100", part)
+		}
+
+		windows = append(windows, models.CancellationWindow{Before: d, FeePercent: fee})
+	}
+	return windows, nil
+}
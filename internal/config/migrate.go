@@ -0,0 +1,186 @@
+package config
+
+import (
+	"barber-backend-api/repository"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// legacyAppointmentLayouts — форматы, в которых время записи хранилось в строковой колонке time.
+var legacyAppointmentLayouts = []string{time.DateTime, "2006-01-02 15"}
+
+// MigrateAppointmentTimes переносит строковую колонку appointments.time в starts_at/ends_at
+// и удаляет её. Старые значения считаются временем в поясе салона loc; записи с неразборчивым
+// временем не переносятся, а мягко удаляются с предупреждением в логе, чтобы не мешать запуску.
+// Если колонки time уже нет, ничего не делает.
+func MigrateAppointmentTimes(db *gorm.DB, logger *slog.Logger, loc *time.Location) error {
+	m := db.Migrator()
+	if !m.HasTable("appointments") || !m.HasColumn("appointments", "time") {
+		return nil
+	}
+
+	logger.Info("перенос времени записей в starts_at/ends_at", "op", "config.migrate.appointment_times")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`ALTER TABLE appointments
+			ADD COLUMN IF NOT EXISTS starts_at timestamptz,
+			ADD COLUMN IF NOT EXISTS ends_at timestamptz`).Error; err != nil {
+			return err
+		}
+
+		type legacyRow struct {
+			ID        uint
+			Time      string
+			CreatedAt time.Time
+			DeletedAt *time.Time
+		}
+		var rows []legacyRow
+		if err := tx.Table("appointments").Select("id, time, created_at, deleted_at").Where("starts_at IS NULL").Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		skipped := 0
+		for _, row := range rows {
+			fields := map[string]any{}
+			start, err := parseLegacyAppointmentTime(row.Time, loc)
+			if err != nil {
+				// Время не разобрать: запись получает время создания, чтобы колонки остались NOT NULL,
+				// и удаляется мягко, чтобы не занимать расписание. Исходное значение остаётся в логе.
+				logger.Warn("время записи не разобрано, запись удалена",
+					"op", "config.migrate.appointment_times",
+					"id", row.ID,
+					"time", row.Time,
+					"error", err,
+				)
+				start = row.CreatedAt
+				if row.DeletedAt == nil {
+					fields["deleted_at"] = time.Now()
+				}
+				skipped++
+			}
+			fields["starts_at"] = start
+			fields["ends_at"] = start.Add(time.Hour)
+			if err := tx.Table("appointments").Where("id = ?", row.ID).Updates(fields).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec("ALTER TABLE appointments DROP COLUMN time").Error; err != nil {
+			return err
+		}
+
+		logger.Info("время записей перенесено",
+			"op", "config.migrate.appointment_times",
+			"rows", len(rows),
+			"skipped", skipped,
+		)
+		return nil
+	})
+}
+
+// MigrateAppointmentStatuses добавляет колонку appointments.status для базы, созданной до появления статусов.
+// Раньше завершённой считалась любая прошедшая запись, поэтому прошедшие записи получают статус completed,
+// остальные — booked. Если колонка уже есть, ничего не делает.
+func MigrateAppointmentStatuses(db *gorm.DB, logger *slog.Logger) error {
+	m := db.Migrator()
+	if !m.HasTable("appointments") || m.HasColumn("appointments", "status") {
+		return nil
+	}
+
+	logger.Info("добавление статусов записей", "op", "config.migrate.appointment_statuses")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE appointments ADD COLUMN status text NOT NULL DEFAULT 'booked'").Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec("UPDATE appointments SET status = 'completed' WHERE ends_at <= now()")
+		if result.Error != nil {
+			return result.Error
+		}
+
+		logger.Info("статусы записей заполнены",
+			"op", "config.migrate.appointment_statuses",
+			"completed", result.RowsAffected,
+		)
+		return nil
+	})
+}
+
+// MigrateAppointmentRatings переносит оценки из колонки appointments.rating в отзывы и удаляет колонку.
+// Переносятся только оценки 1–5 завершённых записей; нулевое значение по умолчанию означало «оценки нет».
+// Вызывается после AutoMigrate, когда таблица reviews уже создана. Если колонки rating нет, ничего не делает.
+func MigrateAppointmentRatings(db *gorm.DB, logger *slog.Logger) error {
+	m := db.Migrator()
+	if !m.HasTable("appointments") || !m.HasColumn("appointments", "rating") {
+		return nil
+	}
+
+	logger.Info("перенос оценок записей в отзывы", "op", "config.migrate.appointment_ratings")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`INSERT INTO reviews (created_at, updated_at, appointment_id, client_id, barber_id, rating, text)
+			SELECT now(), now(), id, client_id, barber_id, rating, ''
+			FROM appointments
+			WHERE rating BETWEEN 1 AND 5 AND status = 'completed' AND deleted_at IS NULL
+			ON CONFLICT (appointment_id) DO NOTHING`)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := tx.Exec(`UPDATE barbers SET avg_rating = COALESCE(
+			(SELECT AVG(rating) FROM reviews WHERE reviews.barber_id = barbers.id AND reviews.deleted_at IS NULL), 0)`).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("ALTER TABLE appointments DROP COLUMN rating").Error; err != nil {
+			return err
+		}
+
+		logger.Info("оценки записей перенесены",
+			"op", "config.migrate.appointment_ratings",
+			"reviews", result.RowsAffected,
+		)
+		return nil
+	})
+}
+
+// MigrateRatingStats заполняет barber_rating_stats для базы, где отзывы появились раньше агрегатов.
+// Вызывается после AutoMigrate; если агрегаты уже есть или опубликованных отзывов нет, ничего не делает.
+func MigrateRatingStats(db *gorm.DB, logger *slog.Logger) error {
+	var stats, reviews int64
+	if err := db.Table("barber_rating_stats").Count(&stats).Error; err != nil {
+		return err
+	}
+	if err := db.Table("reviews").Where("status = 'published' AND deleted_at IS NULL").Count(&reviews).Error; err != nil {
+		return err
+	}
+	if stats > 0 || reviews == 0 {
+		return nil
+	}
+
+	logger.Info("заполнение агрегатов рейтинга", "op", "config.migrate.rating_stats")
+
+	rated, err := repository.RecomputeRatingStats(db)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("агрегаты рейтинга заполнены",
+		"op", "config.migrate.rating_stats",
+		"barbers", rated,
+	)
+	return nil
+}
+
+func parseLegacyAppointmentTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range legacyAppointmentLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("не удалось разобрать время %q", value)
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"barber-backend-api/internal/models"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultNoShowLimit      = 3
+	defaultNoShowWindowDays = 90
+)
+
+// LoadNoShowRule читает правило пометки клиентов за неявки:
+// NO_SHOW_LIMIT — сколько неявок допускается (по умолчанию 3),
+// NO_SHOW_WINDOW_DAYS — за сколько последних дней они считаются (по умолчанию 90),
+// FLAGGED_CLIENT_MODE — reject (запись запрещена) или approval (запись ждёт подтверждения).
+func LoadNoShowRule(logger *slog.Logger) models.NoShowRule {
+	rule := models.NoShowRule{
+		Limit:  loadPositiveInt(logger, "NO_SHOW_LIMIT", defaultNoShowLimit),
+		Window: time.Duration(loadPositiveInt(logger, "NO_SHOW_WINDOW_DAYS", defaultNoShowWindowDays)) * 24 * time.Hour,
+	}
+
+	switch mode := os.Getenv("FLAGGED_CLIENT_MODE"); mode {
+	case "", "reject":
+	case "approval":
+		rule.RequireApproval = true
+	default:
+		logger.Error("некорректный FLAGGED_CLIENT_MODE, допустимо reject или approval", "value", mode)
+		panic("FLAGGED_CLIENT_MODE должен быть reject или approval")
+	}
+
+	logger.Info("правило неявок",
+		"limit", rule.Limit,
+		"window", rule.Window.String(),
+		"require_approval", rule.RequireApproval,
+	)
+	return rule
+}
+
+func loadPositiveInt(logger *slog.Logger, name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		logger.Error("значение должно быть положительным целым числом", "name", name, "value", raw)
+		panic(name + " должен быть положительным целым числом")
+	}
+	return value
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"barber-backend-api/internal/models"
+	"log/slog"
+)
+
+const defaultRatingPriorWeight = 5
+
+// LoadRatingPrior читает RATING_PRIOR_WEIGHT — сколько «средних» отзывов добавляется
+// к отзывам парикмахера при расчёте байесовского рейтинга (по умолчанию 5).
+func LoadRatingPrior(logger *slog.Logger) models.RatingPrior {
+	prior := models.RatingPrior{
+		Weight: float64(loadPositiveInt(logger, "RATING_PRIOR_WEIGHT", defaultRatingPriorWeight)),
+	}
+
+	logger.Info("байесовский рейтинг", "prior_weight", prior.Weight)
+	return prior
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"barber-backend-api/internal/models"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// defaultBlockedWords — основы распространённой брани; слово считается бранным, если начинается с основы.
+// «ё» писать не нужно: при проверке она заменяется на «е».
+var defaultBlockedWords = []string{
+	"хуй", "хуе", "хуя", "пизд", "ебан", "ебал", "ебат", "еблан", "бляд", "блят", "сука", "суки", "мудак", "мудил", "пидор", "пидар", "залуп", "гандон",
+	"fuck", "shit", "bitch", "cunt", "asshole",
+}
+
+// LoadReviewFilter собирает фильтр отзывов: встроенный список брани дополняется
+// REVIEW_BLOCKED_WORDS, а REVIEW_KEYWORDS задаёт запрещённые слова и фразы салона.
+// Оба списка — через запятую, регистр не важен.
+func LoadReviewFilter(logger *slog.Logger) models.ReviewFilter {
+	filter := models.ReviewFilter{
+		BlockedWords: append(append([]string{}, defaultBlockedWords...), splitList(os.Getenv("REVIEW_BLOCKED_WORDS"))...),
+		Keywords:     splitList(os.Getenv("REVIEW_KEYWORDS")),
+	}
+
+	logger.Info("фильтр отзывов",
+		"blocked_words", len(filter.BlockedWords),
+		"keywords", len(filter.Keywords),
+	)
+	return filter
+}
+
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
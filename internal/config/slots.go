@@ -0,0 +1,30 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// defaultSlotMinutes сохраняет прежнюю сетку записи по целым часам.
+const defaultSlotMinutes = 60
+
+// LoadSlotStep возвращает шаг сетки записи из переменной SLOT_MINUTES (15, 30 или 60 минут).
+// Начало записи округляется вверх до ближайшего шага, свободные слоты считаются с этим же шагом.
+func LoadSlotStep(logger *slog.Logger) time.Duration {
+	raw := os.Getenv("SLOT_MINUTES")
+	if raw == "" {
+		logger.Info("SLOT_MINUTES не задан, используется сетка по умолчанию", "minutes", defaultSlotMinutes)
+		return defaultSlotMinutes * time.Minute
+	}
+
+	minutes, err := strconv.Atoi(raw)
+	if err != nil || (minutes != 15 && minutes != 30 && minutes != 60) {
+		logger.Error("некорректный шаг сетки SLOT_MINUTES, допустимо 15, 30 или 60", "value", raw)
+		panic("SLOT_MINUTES должен быть 15, 30 или 60")
+	}
+
+	logger.Info("шаг сетки записи", "minutes", minutes)
+	return time.Duration(minutes) * time.Minute
+}
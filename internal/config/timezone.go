@@ -0,0 +1,26 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"time"
+)
+
+// LoadLocation возвращает часовой пояс салона из переменной SHOP_TIMEZONE (имя IANA, например Europe/Moscow).
+// Все проверки записи, выходные и "прошедшее время" считаются в этом поясе.
+func LoadLocation(logger *slog.Logger) *time.Location {
+	name := os.Getenv("SHOP_TIMEZONE")
+	if name == "" {
+		logger.Warn("SHOP_TIMEZONE не задан, используется часовой пояс сервера", "zone", time.Local.String())
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		logger.Error("неизвестный часовой пояс SHOP_TIMEZONE", "zone", name, "error", err)
+		panic(err)
+	}
+
+	logger.Info("часовой пояс салона", "zone", loc.String())
+	return loc
+}
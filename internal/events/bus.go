@@ -0,0 +1,94 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	AppointmentCreated   = "appointment.created"
+	AppointmentUpdated   = "appointment.updated"
+	AppointmentCancelled = "appointment.cancelled"
+	AppointmentRated     = "appointment.rated"
+)
+
+// subscriberBuffer — сколько событий ждёт медленного подписчика, прежде чем новые начнут отбрасываться.
+const subscriberBuffer = 64
+
+// Event — изменение записи. Содержит только то, что нужно экрану ресепшена, чтобы обновить строку
+// или перезапросить запись по AppointmentID.
+type Event struct {
+	Type          string    `json:"type"`
+	AppointmentID uint      `json:"appointment_id"`
+	BarberID      uint      `json:"barber_id"`
+	ClientID      uint      `json:"client_id"`
+	Status        string    `json:"status"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// Bus — шина событий внутри процесса. Publish никогда не блокирует: если подписчик
+// не успевает читать, событие для него отбрасывается: экран догонит состояние при переподключении.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	C        <-chan Event
+	ch       chan Event
+	barberID uint
+	bus      *Bus
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Subscribe подписывает на события; ненулевой barberID оставляет только события этого парикмахера.
+// Подписку нужно закрыть через Close.
+func (b *Bus) Subscribe(barberID uint) *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, barberID: barberID, bus: b}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers {
+		if sub.barberID != 0 && sub.barberID != event.BarberID {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
+
+// Close отписывает и закрывает канал C. Повторный вызов ничего не делает.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	if _, ok := s.bus.subscribers[s]; !ok {
+		return
+	}
+	delete(s.bus.subscribers, s)
+	close(s.ch)
+}
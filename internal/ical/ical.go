@@ -0,0 +1,150 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type Event struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// Parse читает события VEVENT из календаря в формате iCalendar (RFC 5545).
+// Поддерживаются только поля SUMMARY, DTSTART и DTEND, остальные игнорируются.
+// Даты без часового пояса (целые дни и "плавающее" время) считаются временем в loc.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []Event
+		current *Event
+		hasEnd  bool
+	)
+
+	for _, line := range lines {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+			hasEnd = false
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				return nil, errors.New("ical: END:VEVENT без BEGIN:VEVENT")
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("ical: у события %q нет DTSTART", current.Summary)
+			}
+			if !hasEnd {
+				if current.AllDay {
+					current.End = current.Start.AddDate(0, 0, 1)
+				} else {
+					current.End = current.Start
+				}
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "SUMMARY":
+			current.Summary = unescape(value)
+		case name == "DTSTART":
+			t, allDay, err := parseDate(params, value, loc)
+			if err != nil {
+				return nil, err
+			}
+			current.Start = t
+			current.AllDay = allDay
+		case name == "DTEND":
+			t, _, err := parseDate(params, value, loc)
+			if err != nil {
+				return nil, err
+			}
+			current.End = t
+			hasEnd = true
+		}
+	}
+
+	if current != nil {
+		return nil, errors.New("ical: незакрытый VEVENT")
+	}
+	return events, nil
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func splitLine(line string) (name string, params map[string]string, value string, ok bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(head, ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+func parseDate(params map[string]string, value string, loc *time.Location) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ical: неправильная дата %q", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ical: неправильное время %q", value)
+		}
+		return t, false, nil
+	}
+
+	if tzid, ok := params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("ical: неправильное время %q", value)
+	}
+	return t, false, nil
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+const (
+	AppointmentBooked    = "booked"
+	AppointmentConfirmed = "confirmed"
+	AppointmentCheckedIn = "checked_in"
+	AppointmentCompleted = "completed"
+	AppointmentCancelled = "cancelled"
+	AppointmentNoShow    = "no_show"
+	// AppointmentPendingApproval — запись помеченного клиента, которую должен подтвердить администратор.
+	AppointmentPendingApproval = "pending_approval"
+)
+
+// AppointmentStatusChange — запись в истории статусов. Для только что созданной записи From пустой.
+type AppointmentStatusChange struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	AppointmentID uint      `json:"appointment_id" gorm:"not null;index"`
+	From          string    `json:"from" gorm:"column:from_status"`
+	To            string    `json:"to" gorm:"column:to_status;not null"`
+	ChangedAt     time.Time `json:"changed_at" gorm:"not null"`
+}
+
+// AppointmentReschedule хранит прежнее и новое время записи при каждом переносе.
+type AppointmentReschedule struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	AppointmentID uint      `json:"appointment_id" gorm:"not null;index"`
+	FromBarberID  uint      `json:"from_barber_id" gorm:"not null"`
+	FromStartsAt  time.Time `json:"from_starts_at" gorm:"not null"`
+	FromEndsAt    time.Time `json:"from_ends_at" gorm:"not null"`
+	ToBarberID    uint      `json:"to_barber_id" gorm:"not null"`
+	ToStartsAt    time.Time `json:"to_starts_at" gorm:"not null"`
+	ToEndsAt      time.Time `json:"to_ends_at" gorm:"not null"`
+	RescheduledAt time.Time `json:"rescheduled_at" gorm:"not null"`
+}
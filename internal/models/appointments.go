@@ -1,27 +1,57 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Appointments struct {
 	gorm.Model
-	BarberID uint   `json:"barber_id" gorm:"not null"`
-	Barber   Barber `json:"barber" gorm:"foreignKey:BarberID; not null"`
-	ClientID uint   `json:"client_id" gorm:"not null"`
-	Client   Client `json:"client" gorm:"foreignKey:ClientID; not null"`
-	Time     string `json:"time" gorm:"not null"`
-	Rating   *int   `json:"rating" gorm:"default:0"`
+	BarberID uint      `json:"barber_id" gorm:"not null"`
+	Barber   Barber    `json:"barber" gorm:"foreignKey:BarberID; not null"`
+	ClientID uint      `json:"client_id" gorm:"not null"`
+	Client   Client    `json:"client" gorm:"foreignKey:ClientID; not null"`
+	StartsAt time.Time `json:"starts_at" gorm:"not null;index"`
+	EndsAt   time.Time `json:"ends_at" gorm:"not null"`
+	Services []Service `json:"services" gorm:"many2many:appointment_services"`
+	Price    int64     `json:"price" gorm:"not null;default:0"`
+	// BufferMinutes — пауза после записи, в которую парикмахер не принимает следующего клиента.
+	BufferMinutes int `json:"buffer_minutes" gorm:"not null;default:0"`
+
+	Status  string                    `json:"status" gorm:"not null;default:booked;index"`
+	History []AppointmentStatusChange `json:"history,omitempty" gorm:"foreignKey:AppointmentID"`
+
+	// CancellationFee и CancellationReason — итог применения правил отмены при отмене или неявке.
+	CancellationFee    int64  `json:"cancellation_fee" gorm:"not null;default:0"`
+	CancellationReason string `json:"cancellation_reason,omitempty"`
+
+	Reschedules []AppointmentReschedule `json:"reschedules,omitempty" gorm:"foreignKey:AppointmentID"`
+
+	// SeriesID связывает повторения одной повторяющейся записи.
+	SeriesID *uint `json:"series_id" gorm:"index"`
 }
 
 type AppointmentsCreateDTO struct {
 	BarberID uint   `json:"barber_id" gorm:"not null"`
 	ClientID uint   `json:"client_id" gorm:"not null"`
-	Time     string `json:"time" gorm:"not null"`
+	StartsAt string `json:"starts_at"`
+	// Time — устаревший формат "YYYY-MM-DD HH", принимается на время перехода на starts_at.
+	Time       string `json:"time"`
+	ServiceIDs []uint `json:"service_ids"`
+	// Recurrence — правило повторения в виде подмножества RRULE, например "FREQ=WEEKLY;INTERVAL=3;COUNT=10".
+	Recurrence string `json:"recurrence"`
 }
 
-type AppointmentsUpdateReqDTO struct {
-	BarberID uint `json:"barber_id" gorm:"not null"`
-	ClientID uint   `json:"client_id" gorm:"not null"`
-	Rating   int  `json:"rating" gorm:"default:0"`
+type AppointmentsRescheduleReqDTO struct {
+	StartsAt string `json:"starts_at" binding:"required"`
+	// BarberID — новый парикмахер; если не указан, запись остаётся у прежнего.
+	BarberID *uint `json:"barber_id"`
+}
+
+type AppointmentsNextAvailableReqDTO struct {
+	ClientID   uint    `json:"client_id" binding:"required"`
+	After      string  `json:"after"`
+	MinRating  float64 `json:"min_rating"`
+	ServiceIDs []uint  `json:"service_ids"`
 }
@@ -0,0 +1,25 @@
+package models
+
+type SlotDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type DayAvailabilityDTO struct {
+	Date  string    `json:"date"`
+	Slots []SlotDTO `json:"slots"`
+}
+
+type BarberAvailabilityDTO struct {
+	BarberID uint                 `json:"barber_id"`
+	FullName string               `json:"full_name"`
+	Days     []DayAvailabilityDTO `json:"days"`
+}
+
+type NextSlotDTO struct {
+	BarberID  uint    `json:"barber_id"`
+	FullName  string  `json:"full_name"`
+	AvgRating float64 `json:"avg_rating"`
+	Start     string  `json:"start"`
+	End       string  `json:"end"`
+}
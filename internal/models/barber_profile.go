@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// BarberProfileResDTO — публичная карточка парикмахера. Содержит только то, что можно показать
+// анонимному посетителю: без данных клиентов, внутренних пауз и отзывов до модерации.
+type BarberProfileResDTO struct {
+	ID           uint                             `json:"id"`
+	FullName     string                           `json:"full_name"`
+	WorkingHours map[string][]ScheduleIntervalDTO `json:"working_hours"`
+	Services     []BarberMenuItemDTO              `json:"services"`
+	Rating       *BarberRatingResDTO              `json:"rating"`
+	ReviewCount  int64                            `json:"review_count"`
+	Reviews      []PublicReviewDTO                `json:"reviews"`
+	// NextAvailable — ближайший свободный слот на услугу по умолчанию; null, если в ближайшие две недели мест нет.
+	NextAvailable *SlotDTO `json:"next_available"`
+}
+
+// PublicReviewDTO — опубликованный отзыв без сведений о клиенте и записи.
+type PublicReviewDTO struct {
+	Rating    int        `json:"rating"`
+	Text      string     `json:"text,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	Reply     string     `json:"reply,omitempty"`
+	RepliedAt *time.Time `json:"replied_at,omitempty"`
+}
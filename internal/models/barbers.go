@@ -8,13 +8,30 @@ type Barber struct {
 	WorkHoursStart int     `json:"work_hours_start" gorm:"default:9"`
 	WorkHoursEnd   int     `json:"work_hours_end" gorm:"default:17"`
 	AvgRating      float64 `json:"avg_rating" gorm:"default:0"`
+	// BufferMinutes — время на уборку после каждой записи. Указатель, чтобы PATCH мог сбросить его в 0.
+	BufferMinutes *int `json:"buffer_minutes" gorm:"not null;default:0"`
 }
 
 type BarbersCreateReqDTO struct {
-	FullName  string `json:"full_name" gorm:"not null"`
+	FullName       string `json:"full_name" gorm:"not null"`
+	WorkHoursStart *int   `json:"work_hours_start"`
+	WorkHoursEnd   *int   `json:"work_hours_end"`
+	BufferMinutes  *int   `json:"buffer_minutes"`
+}
+
+type BarbersUpdateReqDTO struct {
+	FullName       *string `json:"full_name"`
+	WorkHoursStart *int    `json:"work_hours_start"`
+	WorkHoursEnd   *int    `json:"work_hours_end"`
+	BufferMinutes  *int    `json:"buffer_minutes"`
 }
 
 type BarberResDTO struct {
-	FullName  string  `json:"full_name"`
-	AvgRating float64 `json:"avg_rating"`
+	ID             uint                `json:"id"`
+	FullName       string              `json:"full_name"`
+	WorkHoursStart int                 `json:"work_hours_start"`
+	WorkHoursEnd   int                 `json:"work_hours_end"`
+	AvgRating      float64             `json:"avg_rating"`
+	BufferMinutes  int                 `json:"buffer_minutes"`
+	Services       []BarberMenuItemDTO `json:"services,omitempty" gorm:"-"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// CancellationWindow — штраф FeePercent от цены записи, если до её начала осталось меньше Before.
+type CancellationWindow struct {
+	Before     time.Duration
+	FeePercent int
+}
+
+// CancellationPolicy описывает правила отмены. Из подходящих окон применяется самое короткое,
+// то есть самое строгое; если ни одно не подошло, отмена бесплатная.
+type CancellationPolicy struct {
+	Windows          []CancellationWindow
+	NoShowFeePercent int
+}
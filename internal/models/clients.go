@@ -1,10 +1,21 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Client struct {
 	gorm.Model
 	FullName   string `json:"full_name" gorm:"not null"`
+	// NoShowCount — сколько всего раз клиент не пришёл.
+	NoShowCount int `json:"no_show_count" gorm:"not null;default:0"`
+	// FlaggedAt — когда клиента пометили за неявки; nil, если метки нет.
+	FlaggedAt  *time.Time `json:"flagged_at"`
+	FlagReason string     `json:"flag_reason,omitempty"`
+	// FlagClearedAt — когда администратор снял метку; неявки до этого момента больше не считаются.
+	FlagClearedAt *time.Time `json:"flag_cleared_at"`
 }
 
 type ClientUpdateReqDTO struct {
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	HoldActive    = "active"
+	HoldConfirmed = "confirmed"
+	HoldReleased  = "released"
+	HoldExpired   = "expired"
+)
+
+const (
+	// HoldCheckout — бронь на время оформления записи в интерфейсе.
+	HoldCheckout = "checkout"
+	// HoldWaitlistOffer — бронь, предложенная клиенту из очереди ожидания.
+	HoldWaitlistOffer = "waitlist"
+)
+
+// SlotHold — временная бронь слота за клиентом. Пока она активна и не истекла,
+// слот считается занятым, а подтверждение превращает бронь в запись.
+type SlotHold struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	BarberID      uint      `json:"barber_id" gorm:"not null;index"`
+	ClientID      uint      `json:"client_id" gorm:"not null"`
+	StartsAt      time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt        time.Time `json:"ends_at" gorm:"not null"`
+	BufferMinutes int       `json:"buffer_minutes" gorm:"not null;default:0"`
+	Services      []Service `json:"services" gorm:"many2many:slot_hold_services"`
+	Price         int64     `json:"price" gorm:"not null;default:0"`
+	ExpiresAt     time.Time `json:"expires_at" gorm:"not null;index"`
+	Status        string    `json:"status" gorm:"not null;default:active;index"`
+	Kind          string    `json:"kind" gorm:"not null;default:checkout"`
+	AppointmentID *uint     `json:"appointment_id"`
+}
+
+type SlotHoldCreateReqDTO struct {
+	BarberID   uint   `json:"barber_id" binding:"required"`
+	ClientID   uint   `json:"client_id" binding:"required"`
+	StartsAt   string `json:"starts_at" binding:"required"`
+	ServiceIDs []uint `json:"service_ids"`
+	// TTLMinutes — на сколько минут держать слот; по умолчанию 10, не больше 30.
+	TTLMinutes *int `json:"ttl_minutes"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Holiday — праздник или закрытие салона для всех парикмахеров.
+// Если ClosedFrom и ClosedTo пустые, салон закрыт весь день.
+type Holiday struct {
+	gorm.Model
+	Date       time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_holiday_date_name"`
+	Name       string    `json:"name" gorm:"not null;uniqueIndex:idx_holiday_date_name"`
+	ClosedFrom *string   `json:"closed_from"`
+	ClosedTo   *string   `json:"closed_to"`
+}
+
+type HolidayCreateReqDTO struct {
+	Date       string  `json:"date" binding:"required"`
+	Name       string  `json:"name" binding:"required"`
+	ClosedFrom *string `json:"closed_from"`
+	ClosedTo   *string `json:"closed_to"`
+}
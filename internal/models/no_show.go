@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// NoShowRule — клиент помечается, если за Window набрал Limit неявок.
+// Пока метка стоит, новые записи отклоняются или, при RequireApproval, ждут подтверждения администратора.
+type NoShowRule struct {
+	Limit           int
+	Window          time.Duration
+	RequireApproval bool
+}
+
+type ClientNoShowsResDTO struct {
+	ClientID      uint       `json:"client_id"`
+	FullName      string     `json:"full_name"`
+	NoShowCount   int        `json:"no_show_count"`
+	RecentNoShows int64      `json:"recent_no_shows"`
+	Flagged       bool       `json:"flagged"`
+	FlaggedAt     *time.Time `json:"flagged_at,omitempty"`
+	FlagReason    string     `json:"flag_reason,omitempty"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// BarberRatingStats — агрегаты опубликованных отзывов о парикмахере. Обновляются в одной транзакции
+// с отзывом, поэтому рейтинг не приходится пересчитывать по всем отзывам.
+type BarberRatingStats struct {
+	BarberID    uint      `json:"barber_id" gorm:"primaryKey;autoIncrement:false"`
+	RatingSum   int64     `json:"rating_sum" gorm:"not null;default:0"`
+	RatingCount int64     `json:"rating_count" gorm:"not null;default:0"`
+	Stars1      int64     `json:"stars_1" gorm:"column:stars_1;not null;default:0"`
+	Stars2      int64     `json:"stars_2" gorm:"column:stars_2;not null;default:0"`
+	Stars3      int64     `json:"stars_3" gorm:"column:stars_3;not null;default:0"`
+	Stars4      int64     `json:"stars_4" gorm:"column:stars_4;not null;default:0"`
+	Stars5      int64     `json:"stars_5" gorm:"column:stars_5;not null;default:0"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RatingPrior — априорное мнение для байесовского среднего: рейтинг парикмахера с малым числом
+// отзывов тянется к средней по салону, как будто у него есть ещё Weight отзывов со средней оценкой.
+type RatingPrior struct {
+	Weight float64
+}
+
+type BarberRatingResDTO struct {
+	BarberID uint `json:"barber_id"`
+	// Average — простое среднее, WeightedAverage — байесовское среднее с учётом RatingPrior.
+	Average         float64       `json:"average"`
+	WeightedAverage float64       `json:"weighted_average"`
+	Count           int64         `json:"count"`
+	Histogram       map[int]int64 `json:"histogram"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ReviewPending   = "pending"
+	ReviewPublished = "published"
+	ReviewHidden    = "hidden"
+)
+
+// Review — отзыв клиента о завершённом визите. На одну запись — один отзыв.
+// В рейтинг парикмахера и публичные списки попадают только опубликованные отзывы.
+type Review struct {
+	gorm.Model
+	AppointmentID uint   `json:"appointment_id" gorm:"not null;uniqueIndex"`
+	ClientID      uint   `json:"client_id" gorm:"not null;index"`
+	BarberID      uint   `json:"barber_id" gorm:"not null;index"`
+	Rating        int    `json:"rating" gorm:"not null"`
+	Text          string `json:"text"`
+
+	// Status по умолчанию published: отзывы, оставленные до появления модерации, уже были видны.
+	Status           string     `json:"status" gorm:"not null;default:published;index"`
+	ModerationReason string     `json:"moderation_reason,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+
+	// Reply — единственный публичный ответ парикмахера на отзыв.
+	Reply     string     `json:"reply,omitempty"`
+	RepliedAt *time.Time `json:"replied_at,omitempty"`
+}
+
+type ReviewCreateReqDTO struct {
+	// ClientID — клиент, оставляющий отзыв; он должен совпадать с клиентом записи.
+	ClientID uint   `json:"client_id" binding:"required"`
+	Rating   int    `json:"rating" binding:"required,min=1,max=5"`
+	Text     string `json:"text" binding:"max=2000"`
+}
+
+type ReviewModerateReqDTO struct {
+	Status string `json:"status" binding:"required,oneof=pending published hidden"`
+	Reason string `json:"reason"`
+}
+
+type ReviewReplyReqDTO struct {
+	BarberID uint   `json:"barber_id" binding:"required"`
+	Text     string `json:"text" binding:"required,max=1000"`
+}
+
+// ReviewFilter — слова, из-за которых отзыв уходит на модерацию. BlockedWords — основы нецензурных слов,
+// совпадают с началом слова; Keywords — слова и фразы, запрещённые салоном (ссылки, названия конкурентов).
+type ReviewFilter struct {
+	BlockedWords []string
+	Keywords     []string
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BarberSchedule struct {
+	gorm.Model
+	BarberID      uint               `json:"barber_id" gorm:"not null;index"`
+	EffectiveFrom time.Time          `json:"effective_from" gorm:"type:date;not null"`
+	EffectiveTo   *time.Time         `json:"effective_to" gorm:"type:date"`
+	Intervals     []ScheduleInterval `json:"intervals" gorm:"foreignKey:ScheduleID;constraint:OnDelete:CASCADE"`
+}
+
+type ScheduleInterval struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	ScheduleID uint   `json:"schedule_id" gorm:"not null;index"`
+	Weekday    int    `json:"weekday" gorm:"not null"`
+	Start      string `json:"start" gorm:"not null"`
+	End        string `json:"end" gorm:"not null"`
+}
+
+type ScheduleIntervalDTO struct {
+	Start string `json:"start" binding:"required"`
+	End   string `json:"end" binding:"required"`
+}
+
+type BarberScheduleReqDTO struct {
+	EffectiveFrom string                           `json:"effective_from" binding:"required"`
+	EffectiveTo   *string                          `json:"effective_to"`
+	Days          map[string][]ScheduleIntervalDTO `json:"days" binding:"required"`
+}
+
+type BarberScheduleResDTO struct {
+	ID            uint                             `json:"id"`
+	BarberID      uint                             `json:"barber_id"`
+	EffectiveFrom string                           `json:"effective_from"`
+	EffectiveTo   *string                          `json:"effective_to"`
+	Days          map[string][]ScheduleIntervalDTO `json:"days"`
+}
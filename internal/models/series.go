@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SeriesScopeThis      = "this"
+	SeriesScopeFollowing = "following"
+	SeriesScopeAll       = "all"
+)
+
+// AppointmentSeries — повторяющаяся запись. Каждое повторение хранится отдельной
+// записью Appointments со ссылкой SeriesID.
+type AppointmentSeries struct {
+	gorm.Model
+	ClientID      uint           `json:"client_id" gorm:"not null;index"`
+	BarberID      uint           `json:"barber_id" gorm:"not null"`
+	Rule          string         `json:"rule" gorm:"not null"`
+	IntervalWeeks int            `json:"interval_weeks" gorm:"not null"`
+	Count         *int           `json:"count"`
+	Until         *time.Time     `json:"until" gorm:"type:timestamptz"`
+	Appointments  []Appointments `json:"appointments,omitempty" gorm:"foreignKey:SeriesID"`
+}
+
+type SeriesConflictDTO struct {
+	AppointmentID uint   `json:"appointment_id,omitempty"`
+	StartsAt      string `json:"starts_at"`
+	Reason        string `json:"reason"`
+}
+
+// SeriesResDTO — результат операции над серией: затронутые записи и повторения, которые не удалось обработать.
+type SeriesResDTO struct {
+	Series       *AppointmentSeries  `json:"series,omitempty"`
+	Appointments []Appointments      `json:"appointments"`
+	Conflicts    []SeriesConflictDTO `json:"conflicts"`
+}
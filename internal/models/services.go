@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Service — услуга из каталога салона. Цена хранится в копейках.
+type Service struct {
+	gorm.Model
+	Name            string `json:"name" gorm:"not null"`
+	DurationMinutes int    `json:"duration_minutes" gorm:"not null"`
+	Price           int64  `json:"price" gorm:"not null;default:0"`
+	Active          bool   `json:"active" gorm:"not null;default:true"`
+	// BufferMinutes — пауза после услуги (например, уборка после окрашивания).
+	BufferMinutes int `json:"buffer_minutes" gorm:"not null;default:0"`
+}
+
+type ServiceCreateReqDTO struct {
+	Name            string `json:"name" binding:"required"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required"`
+	Price           int64  `json:"price"`
+	Active          *bool  `json:"active"`
+	BufferMinutes   int    `json:"buffer_minutes"`
+}
+
+type ServiceUpdateReqDTO struct {
+	Name            *string `json:"name"`
+	DurationMinutes *int    `json:"duration_minutes"`
+	Price           *int64  `json:"price"`
+	Active          *bool   `json:"active"`
+	BufferMinutes   *int    `json:"buffer_minutes"`
+}
+
+// BarberServiceLink — услуга, которую оказывает конкретный парикмахер.
+// Пустые Price и DurationMinutes означают, что берутся значения из каталога.
+type BarberServiceLink struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	BarberID        uint      `json:"barber_id" gorm:"not null;uniqueIndex:idx_barber_service"`
+	ServiceID       uint      `json:"service_id" gorm:"not null;uniqueIndex:idx_barber_service"`
+	Service         Service   `json:"service" gorm:"foreignKey:ServiceID"`
+	Price           *int64    `json:"price"`
+	DurationMinutes *int      `json:"duration_minutes"`
+}
+
+func (BarberServiceLink) TableName() string {
+	return "barber_services"
+}
+
+type BarberServiceReqDTO struct {
+	Price           *int64 `json:"price"`
+	DurationMinutes *int   `json:"duration_minutes"`
+}
+
+type BarberMenuItemDTO struct {
+	ServiceID       uint   `json:"service_id"`
+	Name            string `json:"name"`
+	DurationMinutes int    `json:"duration_minutes"`
+	Price           int64  `json:"price"`
+	Active          bool   `json:"active"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TimeOffVacation = "vacation"
+	TimeOffSick     = "sick"
+	TimeOffOther    = "other"
+)
+
+type TimeOff struct {
+	gorm.Model
+	BarberID    uint       `json:"barber_id" gorm:"not null;index"`
+	Kind        string     `json:"kind" gorm:"not null;default:other"`
+	Start       time.Time  `json:"start" gorm:"column:starts_at;not null"`
+	End         time.Time  `json:"end" gorm:"column:ends_at;not null"`
+	Note        string     `json:"note"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+}
+
+type TimeOffCreateReqDTO struct {
+	Kind  string `json:"kind"`
+	Start string `json:"start" binding:"required"`
+	End   string `json:"end" binding:"required"`
+	Note  string `json:"note"`
+}
+
+type TimeOffCreateResDTO struct {
+	TimeOff   TimeOff        `json:"time_off"`
+	Conflicts []Appointments `json:"conflicts"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	WaitlistWaiting   = "waiting"
+	WaitlistOffered   = "offered"
+	WaitlistBooked    = "booked"
+	WaitlistCancelled = "cancelled"
+	WaitlistExpired   = "expired"
+)
+
+// WaitlistEntry — клиент ждёт освободившееся время у парикмахера в конкретный день.
+// WindowStart и WindowEnd ("HH:MM") ограничивают подходящее время; пустые — подходит весь день.
+type WaitlistEntry struct {
+	gorm.Model
+	ClientID      uint      `json:"client_id" gorm:"not null;index"`
+	BarberID      uint      `json:"barber_id" gorm:"not null;index:idx_waitlist_barber_date"`
+	Date          time.Time `json:"date" gorm:"type:date;not null;index:idx_waitlist_barber_date"`
+	WindowStart   *string   `json:"window_start"`
+	WindowEnd     *string   `json:"window_end"`
+	Services      []Service `json:"services" gorm:"many2many:waitlist_entry_services"`
+	Status        string    `json:"status" gorm:"not null;default:waiting"`
+	HoldID        *uint     `json:"hold_id"`
+	Hold          *SlotHold `json:"hold,omitempty" gorm:"foreignKey:HoldID"`
+	AppointmentID *uint     `json:"appointment_id"`
+}
+
+type WaitlistJoinReqDTO struct {
+	ClientID    uint    `json:"client_id" binding:"required"`
+	BarberID    uint    `json:"barber_id" binding:"required"`
+	Date        string  `json:"date" binding:"required"`
+	WindowStart *string `json:"window_start"`
+	WindowEnd   *string `json:"window_end"`
+	ServiceIDs  []uint  `json:"service_ids"`
+}
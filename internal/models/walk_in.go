@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	WalkInWaiting = "waiting"
+	WalkInCalled  = "called"
+	WalkInLeft    = "left"
+)
+
+// WalkInEntry — клиент без записи в живой очереди. Пустой BarberID означает «к любому парикмахеру».
+type WalkInEntry struct {
+	gorm.Model
+	ClientID       uint       `json:"client_id" gorm:"not null;index"`
+	BarberID       *uint      `json:"barber_id" gorm:"index"`
+	Services       []Service  `json:"services" gorm:"many2many:walk_in_entry_services"`
+	Position       int        `json:"position" gorm:"not null;index"`
+	Status         string     `json:"status" gorm:"not null;default:waiting;index"`
+	CalledBarberID *uint      `json:"called_barber_id"`
+	CalledAt       *time.Time `json:"called_at"`
+	AppointmentID  *uint      `json:"appointment_id"`
+
+	// EstimatedWaitMinutes и EstimatedBarberID считаются при выдаче очереди и не хранятся.
+	EstimatedWaitMinutes *int  `json:"estimated_wait_minutes,omitempty" gorm:"-"`
+	EstimatedBarberID    *uint `json:"estimated_barber_id,omitempty" gorm:"-"`
+}
+
+type WalkInJoinReqDTO struct {
+	ClientID   uint   `json:"client_id" binding:"required"`
+	BarberID   *uint  `json:"barber_id"`
+	ServiceIDs []uint `json:"service_ids"`
+}
+
+type WalkInCallReqDTO struct {
+	BarberID uint `json:"barber_id" binding:"required"`
+}
+
+type WalkInMoveReqDTO struct {
+	Position int `json:"position" binding:"required,min=1"`
+}
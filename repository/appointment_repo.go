@@ -2,20 +2,39 @@ package repository
 
 import (
 	"barber-backend-api/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AppointmentsRepository interface {
 	GetAllAppointments() ([]models.Appointments, error)
 	CreateAppointment(req *models.Appointments) error
-	Update(id uint, req models.AppointmentsUpdateReqDTO) error
-	GetLastAppointments(id uint) (*models.Appointments, error)
 	GetAllAppointmentsByBarberID(id uint) ([]models.Appointments, error)
+	GetByBarberInRange(barberID uint, from, to time.Time) ([]models.Appointments, error)
+	HasOverlap(barberID uint, from, to time.Time, exceptID uint) (bool, error)
 	GetByID(id uint) (*models.Appointments, error)
 	Delete(id uint) error
+	Transaction(fn func(tx AppointmentsRepository) error) error
+	LockBarber(barberID uint) error
+	UpdateStatus(id uint, from, to string) (bool, error)
+	AddStatusChange(change *models.AppointmentStatusChange) error
+	SetCancellation(id uint, fee int64, reason string) error
+	CountNoShows(clientID uint, since time.Time) (int64, error)
+	Reschedule(appointment *models.Appointments) error
+	AddReschedule(reschedule *models.AppointmentReschedule) error
+	CreateSeries(series *models.AppointmentSeries) error
+	GetSeries(id uint) (*models.AppointmentSeries, error)
+	GetBySeries(seriesID uint) ([]models.Appointments, error)
+	Clients() ClientsRepository
+	Waitlist() WaitlistRepository
+	SlotHoldsRepository
 }
 
+// releasedStatuses — статусы, при которых запись больше не занимает время парикмахера.
+var releasedStatuses = []string{models.AppointmentCancelled, models.AppointmentNoShow}
+
 type appointmentsRepository struct {
 	db *gorm.DB
 }
@@ -27,7 +46,7 @@ func NewAppointmentsRepository(db *gorm.DB) AppointmentsRepository {
 func (r *appointmentsRepository) GetAllAppointments() ([]models.Appointments, error) {
 	var appointments []models.Appointments
 
-	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").Find(&appointments).Error; err != nil {
+	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").Preload("Services").Find(&appointments).Error; err != nil {
 		return nil, err
 	}
 
@@ -36,7 +55,11 @@ func (r *appointmentsRepository) GetAllAppointments() ([]models.Appointments, er
 func (r *appointmentsRepository) GetByID(id uint) (*models.Appointments, error) {
 	var appnmts models.Appointments
 
-	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").First(&appnmts, id).Error; err != nil {
+	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").Preload("Services").Preload("History", func(db *gorm.DB) *gorm.DB {
+		return db.Order("changed_at, id")
+	}).Preload("Reschedules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("rescheduled_at, id")
+	}).First(&appnmts, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -44,30 +67,149 @@ func (r *appointmentsRepository) GetByID(id uint) (*models.Appointments, error)
 }
 func (r *appointmentsRepository) GetAllAppointmentsByBarberID(id uint) ([]models.Appointments, error) {
 	var appnmtsBarber []models.Appointments
-	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").Where("barber_id = ?", id).Find(&appnmtsBarber).Error; err != nil {
+	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").Preload("Services").Where("barber_id = ?", id).Order("starts_at").Find(&appnmtsBarber).Error; err != nil {
 		return nil, err
 	}
 	return appnmtsBarber, nil
 }
 
+func (r *appointmentsRepository) GetByBarberInRange(barberID uint, from, to time.Time) ([]models.Appointments, error) {
+	var appointments []models.Appointments
+	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").Preload("Services").
+		Where("barber_id = ? AND starts_at < ? AND ends_at + buffer_minutes * interval '1 minute' > ?", barberID, to, from).
+		Where("status NOT IN ?", releasedStatuses).
+		Order("starts_at").Find(&appointments).Error; err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// HasOverlap проверяет, пересекается ли [from, to) с записями парикмахера с учётом паузы после каждой из них.
+// Отменённые записи и неявки время не занимают; запись exceptID не учитывается (0 — проверять все).
+func (r *appointmentsRepository) HasOverlap(barberID uint, from, to time.Time, exceptID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Appointments{}).
+		Where("barber_id = ? AND starts_at < ? AND ends_at + buffer_minutes * interval '1 minute' > ?", barberID, to, from).
+		Where("status NOT IN ?", releasedStatuses).
+		Where("id <> ?", exceptID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *appointmentsRepository) CreateAppointment(req *models.Appointments) error {
 	if req == nil {
 		return nil
 	}
 	return r.db.Create(req).Error
 }
-func (r *appointmentsRepository) Update(id uint, req models.AppointmentsUpdateReqDTO) error {
-	return r.db.Model(&models.Appointments{}).Where("id = ?", id).Update("rating", req.Rating).Error
+func (r *appointmentsRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Appointments{}, id).Error
+}
+
+// Transaction выполняет fn в одной транзакции БД; репозиторий, переданный в fn, работает внутри неё.
+func (r *appointmentsRepository) Transaction(fn func(tx AppointmentsRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&appointmentsRepository{db: tx})
+	})
+}
+
+// Clients возвращает репозиторий клиентов на том же подключении: внутри Transaction
+// изменения клиента фиксируются или откатываются вместе с записью.
+func (r *appointmentsRepository) Clients() ClientsRepository {
+	return &clientsRepository{db: r.db}
+}
+
+// Waitlist возвращает репозиторий листа ожидания на том же подключении,
+// чтобы предложение слота и его бронь создавались в одной транзакции.
+func (r *appointmentsRepository) Waitlist() WaitlistRepository {
+	return &waitlistRepository{db: r.db}
+}
+
+// LockBarber блокирует строку парикмахера до конца транзакции (SELECT ... FOR UPDATE),
+// чтобы параллельные записи к одному парикмахеру выполнялись по очереди.
+func (r *appointmentsRepository) LockBarber(barberID uint) error {
+	var barber models.Barber
+	return r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&barber, barberID).Error
 }
 
-func (r *appointmentsRepository) GetLastAppointments(id uint) (*models.Appointments, error) {
-	var lastAppointments models.Appointments
-	if err := r.db.Model(&models.Appointments{}).Where("barber_id = ?", id).Last(&lastAppointments).Error; err != nil {
+// UpdateStatus меняет статус записи, только если он всё ещё равен from.
+// false означает, что статус успели изменить параллельно.
+func (r *appointmentsRepository) UpdateStatus(id uint, from, to string) (bool, error) {
+	result := r.db.Model(&models.Appointments{}).Where("id = ? AND status = ?", id, from).Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *appointmentsRepository) AddStatusChange(change *models.AppointmentStatusChange) error {
+	if change == nil {
+		return nil
+	}
+	return r.db.Create(change).Error
+}
+
+func (r *appointmentsRepository) SetCancellation(id uint, fee int64, reason string) error {
+	return r.db.Model(&models.Appointments{}).Where("id = ?", id).
+		Updates(map[string]any{"cancellation_fee": fee, "cancellation_reason": reason}).Error
+}
+
+// CountNoShows считает неявки клиента на записи, начинавшиеся не раньше since.
+func (r *appointmentsRepository) CountNoShows(clientID uint, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Appointments{}).
+		Where("client_id = ? AND status = ? AND starts_at >= ?", clientID, models.AppointmentNoShow, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Reschedule сохраняет новые парикмахера, время, цену и услуги записи.
+func (r *appointmentsRepository) Reschedule(appointment *models.Appointments) error {
+	if err := r.db.Model(&models.Appointments{}).Where("id = ?", appointment.ID).Updates(map[string]any{
+		"barber_id":      appointment.BarberID,
+		"starts_at":      appointment.StartsAt,
+		"ends_at":        appointment.EndsAt,
+		"price":          appointment.Price,
+		"buffer_minutes": appointment.BufferMinutes,
+	}).Error; err != nil {
+		return err
+	}
+	return r.db.Model(appointment).Association("Services").Replace(appointment.Services)
+}
+
+func (r *appointmentsRepository) AddReschedule(reschedule *models.AppointmentReschedule) error {
+	if reschedule == nil {
+		return nil
+	}
+	return r.db.Create(reschedule).Error
+}
+
+func (r *appointmentsRepository) CreateSeries(series *models.AppointmentSeries) error {
+	if series == nil {
+		return nil
+	}
+	return r.db.Create(series).Error
+}
+
+func (r *appointmentsRepository) GetSeries(id uint) (*models.AppointmentSeries, error) {
+	var series models.AppointmentSeries
+
+	if err := r.db.First(&series, id).Error; err != nil {
 		return nil, err
 	}
-	return &lastAppointments, nil
+
+	return &series, nil
 }
 
-func (r *appointmentsRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Appointments{}, id).Error
+func (r *appointmentsRepository) GetBySeries(seriesID uint) ([]models.Appointments, error) {
+	var appointments []models.Appointments
+	if err := r.db.Model(&models.Appointments{}).Preload("Barber").Preload("Client").Preload("Services").
+		Where("series_id = ?", seriesID).Order("starts_at").Find(&appointments).Error; err != nil {
+		return nil, err
+	}
+	return appointments, nil
 }
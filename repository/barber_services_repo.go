@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BarberServicesRepository interface {
+	GetByBarberID(barberID uint) ([]models.BarberServiceLink, error)
+	Upsert(link *models.BarberServiceLink) error
+	Delete(barberID, serviceID uint) error
+}
+
+type barberServicesRepository struct {
+	db *gorm.DB
+}
+
+func NewBarberServicesRepository(db *gorm.DB) BarberServicesRepository {
+	return &barberServicesRepository{db: db}
+}
+
+func (r *barberServicesRepository) GetByBarberID(barberID uint) ([]models.BarberServiceLink, error) {
+	var links []models.BarberServiceLink
+
+	if err := r.db.Preload("Service").Where("barber_id = ?", barberID).Order("service_id").Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *barberServicesRepository) Upsert(link *models.BarberServiceLink) error {
+	if link == nil {
+		return nil
+	}
+	return r.db.Omit("Service").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "barber_id"}, {Name: "service_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "duration_minutes", "updated_at"}),
+	}).Create(link).Error
+}
+
+func (r *barberServicesRepository) Delete(barberID, serviceID uint) error {
+	result := r.db.Where("barber_id = ? AND service_id = ?", barberID, serviceID).Delete(&models.BarberServiceLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ type BarbersRepository interface {
 	AddBarber(req *models.Barber) error
 	Update(id uint, barber models.Barber) error
 	GetAll() ([]models.BarberResDTO, error)
+	GetAllBarbers() ([]models.Barber, error)
 	GetBarberByID(id uint) (*models.Barber, error)
 	Delete(id uint) error
 	Exists(id uint) (bool, error)
@@ -117,6 +118,27 @@ func (r *barbersRepository) GetAll() ([]models.BarberResDTO, error) {
 	return barbers, nil
 }
 
+func (r *barbersRepository) GetAllBarbers() ([]models.Barber, error) {
+	r.logger.Debug("получение всех парикмахеров с часами работы",
+		"op", "repo.barber.get_all_barbers",
+	)
+
+	var barbers []models.Barber
+	if err := r.db.Order("id").Find(&barbers).Error; err != nil {
+		r.logger.Error("не удалось получить парикмахеров",
+			"op", "repo.barber.get_all_barbers",
+			"error", err,
+		)
+		return nil, err
+	}
+
+	r.logger.Debug("получение всех парикмахеров прошло успешно",
+		"op", "repo.barber.get_all_barbers",
+		"rows", len(barbers),
+	)
+	return barbers, nil
+}
+
 func (r *barbersRepository) GetBarberByID(id uint) (*models.Barber, error) {
 	r.logger.Debug("получение barber по ID",
 		"op", "repo.barber.get_by_id",
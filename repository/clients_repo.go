@@ -2,6 +2,7 @@ package repository
 
 import (
 	"barber-backend-api/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -13,6 +14,10 @@ type ClientsRepository interface {
 	Update(id uint, client models.ClientUpdateReqDTO) error
 	Delete(id uint) error
 	Exists(id uint) (bool, error)
+	IncrementNoShows(id uint) error
+	SetFlag(id uint, at time.Time, reason string) error
+	ClearFlag(id uint, at time.Time) error
+	GetFlagged() ([]models.Client, error)
 }
 
 type clientsRepository struct {
@@ -65,4 +70,29 @@ func (r *clientsRepository) Exists(id uint) (bool, error){
 		return false, err
 	}
 	return count > 0, nil
-}
\ No newline at end of file
+}
+
+func (r *clientsRepository) IncrementNoShows(id uint) error {
+	return r.db.Model(&models.Client{}).Where("id = ?", id).
+		Update("no_show_count", gorm.Expr("no_show_count + 1")).Error
+}
+
+func (r *clientsRepository) SetFlag(id uint, at time.Time, reason string) error {
+	return r.db.Model(&models.Client{}).Where("id = ? AND flagged_at IS NULL", id).
+		Updates(map[string]any{"flagged_at": at, "flag_reason": reason}).Error
+}
+
+func (r *clientsRepository) ClearFlag(id uint, at time.Time) error {
+	return r.db.Model(&models.Client{}).Where("id = ?", id).
+		Updates(map[string]any{"flagged_at": nil, "flag_reason": "", "flag_cleared_at": at}).Error
+}
+
+func (r *clientsRepository) GetFlagged() ([]models.Client, error) {
+	var clients []models.Client
+
+	if err := r.db.Where("flagged_at IS NOT NULL").Order("flagged_at DESC").Find(&clients).Error; err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
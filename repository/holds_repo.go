@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SlotHoldsRepository — временные брони слотов. Методы входят в AppointmentsRepository,
+// чтобы проверка занятости и создание брони шли в одной транзакции с блокировкой парикмахера.
+type SlotHoldsRepository interface {
+	CreateHold(hold *models.SlotHold) error
+	GetHold(id uint) (*models.SlotHold, error)
+	HasActiveHold(barberID uint, from, to, now time.Time, exceptID uint) (bool, error)
+	GetActiveHoldsInRange(barberID uint, from, to, now time.Time) ([]models.SlotHold, error)
+	UpdateHoldStatus(id uint, from, to string) (bool, error)
+	SetHoldAppointment(id, appointmentID uint) error
+	ExpireHolds(now time.Time) ([]models.SlotHold, error)
+}
+
+func (r *appointmentsRepository) CreateHold(hold *models.SlotHold) error {
+	if hold == nil {
+		return nil
+	}
+	return r.db.Create(hold).Error
+}
+
+func (r *appointmentsRepository) GetHold(id uint) (*models.SlotHold, error) {
+	var hold models.SlotHold
+
+	if err := r.db.Preload("Services").First(&hold, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// activeHolds отбирает брони, которые на момент now ещё держат слот.
+func activeHolds(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("status = ? AND expires_at > ?", models.HoldActive, now)
+}
+
+// HasActiveHold проверяет, пересекается ли [from, to) с действующими бронями парикмахера
+// с учётом паузы после них; бронь exceptID не учитывается (0 — проверять все).
+func (r *appointmentsRepository) HasActiveHold(barberID uint, from, to, now time.Time, exceptID uint) (bool, error) {
+	var count int64
+	if err := activeHolds(r.db.Model(&models.SlotHold{}), now).
+		Where("barber_id = ? AND starts_at < ? AND ends_at + buffer_minutes * interval '1 minute' > ?", barberID, to, from).
+		Where("id <> ?", exceptID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *appointmentsRepository) GetActiveHoldsInRange(barberID uint, from, to, now time.Time) ([]models.SlotHold, error) {
+	var holds []models.SlotHold
+	if err := activeHolds(r.db, now).
+		Where("barber_id = ? AND starts_at < ? AND ends_at + buffer_minutes * interval '1 minute' > ?", barberID, to, from).
+		Order("starts_at").Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// UpdateHoldStatus меняет статус брони, только если он всё ещё равен from.
+func (r *appointmentsRepository) UpdateHoldStatus(id uint, from, to string) (bool, error) {
+	result := r.db.Model(&models.SlotHold{}).Where("id = ? AND status = ?", id, from).Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *appointmentsRepository) SetHoldAppointment(id, appointmentID uint) error {
+	return r.db.Model(&models.SlotHold{}).Where("id = ?", id).Update("appointment_id", appointmentID).Error
+}
+
+// ExpireHolds помечает истёкшими все активные брони с expires_at не позже now и возвращает их.
+func (r *appointmentsRepository) ExpireHolds(now time.Time) ([]models.SlotHold, error) {
+	var holds []models.SlotHold
+	if err := r.db.Model(&holds).Clauses(clause.Returning{}).
+		Where("status = ? AND expires_at <= ?", models.HoldActive, now).
+		Update("status", models.HoldExpired).Error; err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HolidaysRepository interface {
+	Create(holiday *models.Holiday) error
+	CreateBatch(holidays []models.Holiday) (int64, error)
+	GetInRange(from, to time.Time) ([]models.Holiday, error)
+	GetByDate(day time.Time) ([]models.Holiday, error)
+	Delete(id uint) error
+}
+
+type holidaysRepository struct {
+	db *gorm.DB
+}
+
+func NewHolidaysRepository(db *gorm.DB) HolidaysRepository {
+	return &holidaysRepository{db: db}
+}
+
+func (r *holidaysRepository) Create(holiday *models.Holiday) error {
+	if holiday == nil {
+		return nil
+	}
+	return r.db.Create(holiday).Error
+}
+
+// CreateBatch сохраняет праздники, пропуская уже существующие с той же датой и названием.
+func (r *holidaysRepository) CreateBatch(holidays []models.Holiday) (int64, error) {
+	if len(holidays) == 0 {
+		return 0, nil
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&holidays)
+	return result.RowsAffected, result.Error
+}
+
+func (r *holidaysRepository) GetInRange(from, to time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+
+	if err := r.db.Where("date >= ? AND date < ?", from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Order("date").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+func (r *holidaysRepository) GetByDate(day time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+
+	if err := r.db.Where("date = ?", day.Format(time.DateOnly)).Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+func (r *holidaysRepository) Delete(id uint) error {
+	result := r.db.Unscoped().Delete(&models.Holiday{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
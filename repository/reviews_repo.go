@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewsRepository interface {
+	Create(review *models.Review) (bool, error)
+	GetByID(id uint) (*models.Review, error)
+	GetByAppointmentID(appointmentID uint) (*models.Review, error)
+	GetPublishedByBarberID(barberID uint, limit int) ([]models.Review, error)
+	GetByStatus(status string) ([]models.Review, error)
+	UpdateStatus(id uint, from, to, reason string, at time.Time) (bool, error)
+	SetReply(id uint, text string, at time.Time) (bool, error)
+	GetRatingStats(barberID uint) (*models.BarberRatingStats, error)
+	GetGlobalRating() (sum, count int64, err error)
+	RecomputeRatings() (int64, error)
+}
+
+type reviewsRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewsRepository(db *gorm.DB) ReviewsRepository {
+	return &reviewsRepository{db: db}
+}
+
+// Create сохраняет отзыв и, если он сразу опубликован, учитывает его в рейтинге парикмахера в той же транзакции.
+// Строка записи блокируется, поэтому два одновременных отзыва на один визит не пройдут;
+// false означает, что отзыв на эту запись уже есть.
+func (r *reviewsRepository) Create(review *models.Review) (bool, error) {
+	if review == nil {
+		return false, nil
+	}
+
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var appointment models.Appointments
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&appointment, review.AppointmentID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Review{}).Where("appointment_id = ?", review.AppointmentID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		created = true
+
+		if review.Status != models.ReviewPublished {
+			return nil
+		}
+		return applyRating(tx, review.BarberID, review.Rating, 1)
+	})
+	return created, err
+}
+
+func (r *reviewsRepository) GetByID(id uint) (*models.Review, error) {
+	var review models.Review
+
+	if err := r.db.First(&review, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *reviewsRepository) GetByAppointmentID(appointmentID uint) (*models.Review, error) {
+	var review models.Review
+
+	if err := r.db.Where("appointment_id = ?", appointmentID).First(&review).Error; err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// GetPublishedByBarberID возвращает опубликованные отзывы о парикмахере, сначала новые;
+// limit больше нуля оставляет только столько последних отзывов.
+func (r *reviewsRepository) GetPublishedByBarberID(barberID uint, limit int) ([]models.Review, error) {
+	var reviews []models.Review
+
+	query := r.db.Where("barber_id = ? AND status = ?", barberID, models.ReviewPublished).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// GetByStatus возвращает отзывы для модерации, сначала старые; пустой status — все отзывы.
+func (r *reviewsRepository) GetByStatus(status string) ([]models.Review, error) {
+	var reviews []models.Review
+
+	query := r.db.Model(&models.Review{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at, id").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// UpdateStatus меняет статус отзыва, только если он всё ещё равен from. Если отзыв
+// публикуется или снимается с публикации, рейтинг парикмахера меняется в той же транзакции.
+func (r *reviewsRepository) UpdateStatus(id uint, from, to, reason string, at time.Time) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.Select("id", "barber_id", "rating").First(&review, id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Review{}).Where("id = ? AND status = ?", id, from).Updates(map[string]any{
+			"status":            to,
+			"moderation_reason": reason,
+			"moderated_at":      at,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		updated = true
+
+		switch {
+		case from == models.ReviewPublished && to != models.ReviewPublished:
+			return applyRating(tx, review.BarberID, review.Rating, -1)
+		case from != models.ReviewPublished && to == models.ReviewPublished:
+			return applyRating(tx, review.BarberID, review.Rating, 1)
+		}
+		return nil
+	})
+	return updated, err
+}
+
+// SetReply сохраняет ответ парикмахера, только если ответа ещё нет.
+func (r *reviewsRepository) SetReply(id uint, text string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Review{}).Where("id = ? AND (reply IS NULL OR reply = '')", id).Updates(map[string]any{
+		"reply":      text,
+		"replied_at": at,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *reviewsRepository) GetRatingStats(barberID uint) (*models.BarberRatingStats, error) {
+	stats := models.BarberRatingStats{BarberID: barberID}
+
+	if err := r.db.Where("barber_id = ?", barberID).Limit(1).Find(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetGlobalRating возвращает сумму и число опубликованных оценок по всему салону.
+func (r *reviewsRepository) GetGlobalRating() (sum, count int64, err error) {
+	var total struct {
+		Sum   int64
+		Count int64
+	}
+	err = r.db.Model(&models.BarberRatingStats{}).
+		Select("COALESCE(SUM(rating_sum), 0) AS sum, COALESCE(SUM(rating_count), 0) AS count").
+		Scan(&total).Error
+	return total.Sum, total.Count, err
+}
+
+// RecomputeRatings заново собирает агрегаты всех парикмахеров по опубликованным отзывам
+// и возвращает число парикмахеров, у которых есть оценки.
+func (r *reviewsRepository) RecomputeRatings() (int64, error) {
+	return RecomputeRatingStats(r.db)
+}
+
+// RecomputeRatingStats пересобирает barber_rating_stats и barbers.avg_rating с нуля в одной транзакции.
+// Вынесена отдельно, чтобы ею пользовались и репозиторий, и миграция при первом запуске.
+func RecomputeRatingStats(db *gorm.DB) (int64, error) {
+	var rated int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM barber_rating_stats").Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(`INSERT INTO barber_rating_stats
+			(barber_id, rating_sum, rating_count, stars_1, stars_2, stars_3, stars_4, stars_5, updated_at)
+			SELECT barber_id, SUM(rating), COUNT(*),
+				COUNT(*) FILTER (WHERE rating = 1), COUNT(*) FILTER (WHERE rating = 2), COUNT(*) FILTER (WHERE rating = 3),
+				COUNT(*) FILTER (WHERE rating = 4), COUNT(*) FILTER (WHERE rating = 5), now()
+			FROM reviews
+			WHERE status = ? AND deleted_at IS NULL
+			GROUP BY barber_id`, models.ReviewPublished)
+		if result.Error != nil {
+			return result.Error
+		}
+		rated = result.RowsAffected
+
+		return tx.Exec(`UPDATE barbers SET avg_rating = COALESCE(
+			(SELECT rating_sum::float8 / NULLIF(rating_count, 0) FROM barber_rating_stats WHERE barber_id = barbers.id), 0)`).Error
+	})
+	return rated, err
+}
+
+// applyRating добавляет (sign = 1) или убирает (sign = -1) оценку stars из агрегатов парикмахера
+// и обновляет barbers.avg_rating; вызывается внутри транзакции.
+func applyRating(tx *gorm.DB, barberID uint, stars int, sign int64) error {
+	if stars < 1 || stars > 5 {
+		return fmt.Errorf("оценка %d вне диапазона 1–5", stars)
+	}
+	column := fmt.Sprintf("stars_%d", stars)
+
+	if err := tx.Exec(fmt.Sprintf(`INSERT INTO barber_rating_stats (barber_id, rating_sum, rating_count, %[1]s, updated_at)
+		VALUES (?, ?, ?, ?, now())
+		ON CONFLICT (barber_id) DO UPDATE SET
+			rating_sum = barber_rating_stats.rating_sum + EXCLUDED.rating_sum,
+			rating_count = barber_rating_stats.rating_count + EXCLUDED.rating_count,
+			%[1]s = barber_rating_stats.%[1]s + EXCLUDED.%[1]s,
+			updated_at = EXCLUDED.updated_at`, column),
+		barberID, sign*int64(stars), sign, sign).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`UPDATE barbers SET avg_rating = COALESCE(
+		(SELECT rating_sum::float8 / NULLIF(rating_count, 0) FROM barber_rating_stats WHERE barber_id = ?), 0)
+		WHERE id = ?`, barberID, barberID).Error
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SchedulesRepository interface {
+	Create(schedule *models.BarberSchedule) error
+	GetByID(id uint) (*models.BarberSchedule, error)
+	GetByBarberID(barberID uint) ([]models.BarberSchedule, error)
+	GetEffective(barberID uint, day time.Time) (*models.BarberSchedule, error)
+	Update(schedule *models.BarberSchedule) error
+	Delete(id uint) error
+}
+
+type schedulesRepository struct {
+	db *gorm.DB
+}
+
+func NewSchedulesRepository(db *gorm.DB) SchedulesRepository {
+	return &schedulesRepository{db: db}
+}
+
+func (r *schedulesRepository) Create(schedule *models.BarberSchedule) error {
+	if schedule == nil {
+		return nil
+	}
+	return r.db.Create(schedule).Error
+}
+
+func (r *schedulesRepository) GetByID(id uint) (*models.BarberSchedule, error) {
+	var schedule models.BarberSchedule
+
+	if err := r.db.Preload("Intervals").First(&schedule, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *schedulesRepository) GetByBarberID(barberID uint) ([]models.BarberSchedule, error) {
+	var schedules []models.BarberSchedule
+
+	if err := r.db.Preload("Intervals").Where("barber_id = ?", barberID).Order("effective_from").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// GetEffective возвращает график, действующий в указанный день.
+// Если подходящих графиков несколько, берётся начавший действовать позже всех.
+// Если графика нет, возвращается nil без ошибки.
+func (r *schedulesRepository) GetEffective(barberID uint, day time.Time) (*models.BarberSchedule, error) {
+	var schedule models.BarberSchedule
+	date := day.Format(time.DateOnly)
+
+	err := r.db.Preload("Intervals").
+		Where("barber_id = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to >= ?)", barberID, date, date).
+		Order("effective_from DESC").
+		First(&schedule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *schedulesRepository) Update(schedule *models.BarberSchedule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id = ?", schedule.ID).Delete(&models.ScheduleInterval{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.BarberSchedule{}).Where("id = ?", schedule.ID).
+			Updates(map[string]any{
+				"effective_from": schedule.EffectiveFrom,
+				"effective_to":   schedule.EffectiveTo,
+			}).Error; err != nil {
+			return err
+		}
+
+		for i := range schedule.Intervals {
+			schedule.Intervals[i].ID = 0
+			schedule.Intervals[i].ScheduleID = schedule.ID
+		}
+		if len(schedule.Intervals) == 0 {
+			return nil
+		}
+		return tx.Create(&schedule.Intervals).Error
+	})
+}
+
+func (r *schedulesRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id = ?", id).Delete(&models.ScheduleInterval{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.BarberSchedule{}, id).Error
+	})
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ServicesRepository interface {
+	Create(req *models.Service) error
+	GetAll(activeOnly bool) ([]models.Service, error)
+	GetByID(id uint) (*models.Service, error)
+	GetByIDs(ids []uint) ([]models.Service, error)
+	Update(id uint, fields map[string]any) error
+	Delete(id uint) error
+}
+
+type servicesRepository struct {
+	db *gorm.DB
+}
+
+func NewServicesRepository(db *gorm.DB) ServicesRepository {
+	return &servicesRepository{db: db}
+}
+
+func (r *servicesRepository) Create(req *models.Service) error {
+	if req == nil {
+		return nil
+	}
+	return r.db.Create(req).Error
+}
+
+func (r *servicesRepository) GetAll(activeOnly bool) ([]models.Service, error) {
+	var services []models.Service
+
+	query := r.db.Model(&models.Service{}).Order("id")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Find(&services).Error; err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+func (r *servicesRepository) GetByID(id uint) (*models.Service, error) {
+	var service models.Service
+
+	if err := r.db.First(&service, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+func (r *servicesRepository) GetByIDs(ids []uint) ([]models.Service, error) {
+	var services []models.Service
+
+	if err := r.db.Where("id IN ?", ids).Find(&services).Error; err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+func (r *servicesRepository) Update(id uint, fields map[string]any) error {
+	result := r.db.Model(&models.Service{}).Where("id = ?", id).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *servicesRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Service{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TimeOffRepository interface {
+	Create(timeOff *models.TimeOff) error
+	GetByID(id uint) (*models.TimeOff, error)
+	GetByBarberID(barberID uint) ([]models.TimeOff, error)
+	GetActiveInRange(barberID uint, from, to time.Time) ([]models.TimeOff, error)
+	Cancel(id uint, at time.Time) error
+}
+
+type timeOffRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeOffRepository(db *gorm.DB) TimeOffRepository {
+	return &timeOffRepository{db: db}
+}
+
+func (r *timeOffRepository) Create(timeOff *models.TimeOff) error {
+	if timeOff == nil {
+		return nil
+	}
+	return r.db.Create(timeOff).Error
+}
+
+func (r *timeOffRepository) GetByID(id uint) (*models.TimeOff, error) {
+	var timeOff models.TimeOff
+
+	if err := r.db.First(&timeOff, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &timeOff, nil
+}
+
+func (r *timeOffRepository) GetByBarberID(barberID uint) ([]models.TimeOff, error) {
+	var timeOffs []models.TimeOff
+
+	if err := r.db.Where("barber_id = ?", barberID).Order("starts_at").Find(&timeOffs).Error; err != nil {
+		return nil, err
+	}
+
+	return timeOffs, nil
+}
+
+func (r *timeOffRepository) GetActiveInRange(barberID uint, from, to time.Time) ([]models.TimeOff, error) {
+	var timeOffs []models.TimeOff
+
+	if err := r.db.Where("barber_id = ? AND cancelled_at IS NULL AND starts_at < ? AND ends_at > ?", barberID, to, from).
+		Order("starts_at").Find(&timeOffs).Error; err != nil {
+		return nil, err
+	}
+
+	return timeOffs, nil
+}
+
+func (r *timeOffRepository) Cancel(id uint, at time.Time) error {
+	result := r.db.Model(&models.TimeOff{}).Where("id = ? AND cancelled_at IS NULL", id).Update("cancelled_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
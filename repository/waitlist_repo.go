@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WaitlistRepository interface {
+	Create(entry *models.WaitlistEntry) error
+	GetByID(id uint) (*models.WaitlistEntry, error)
+	GetAll(barberID uint, date *time.Time) ([]models.WaitlistEntry, error)
+	GetWaiting(barberID uint, date time.Time) ([]models.WaitlistEntry, error)
+	GetByHoldID(holdID uint) (*models.WaitlistEntry, error)
+	UpdateStatus(id uint, from string, fields map[string]any) (bool, error)
+}
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+func (r *waitlistRepository) Create(entry *models.WaitlistEntry) error {
+	if entry == nil {
+		return nil
+	}
+	return r.db.Create(entry).Error
+}
+
+func (r *waitlistRepository) GetByID(id uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+
+	if err := r.db.Preload("Services").Preload("Hold").First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetAll возвращает очередь ожидания; нулевой barberID и пустая date означают «без фильтра».
+func (r *waitlistRepository) GetAll(barberID uint, date *time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+
+	query := r.db.Preload("Services").Preload("Hold")
+	if barberID != 0 {
+		query = query.Where("barber_id = ?", barberID)
+	}
+	if date != nil {
+		query = query.Where("date = ?", date.Format(time.DateOnly))
+	}
+	if err := query.Order("date, created_at, id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetWaiting возвращает ожидающих клиентов на день в порядке записи в очередь.
+func (r *waitlistRepository) GetWaiting(barberID uint, date time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+
+	if err := r.db.Preload("Services").
+		Where("barber_id = ? AND date = ? AND status = ?", barberID, date.Format(time.DateOnly), models.WaitlistWaiting).
+		Order("created_at, id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *waitlistRepository) GetByHoldID(holdID uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+
+	if err := r.db.Where("hold_id = ?", holdID).First(&entry).Error; err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// UpdateStatus обновляет запись в очереди, только если её статус всё ещё равен from.
+func (r *waitlistRepository) UpdateStatus(id uint, from string, fields map[string]any) (bool, error) {
+	result := r.db.Model(&models.WaitlistEntry{}).Where("id = ? AND status = ?", id, from).Updates(fields)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
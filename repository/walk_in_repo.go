@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"barber-backend-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type WalkInRepository interface {
+	Create(entry *models.WalkInEntry) (bool, error)
+	GetByID(id uint) (*models.WalkInEntry, error)
+	GetWaiting() ([]models.WalkInEntry, error)
+	UpdateStatus(id uint, from string, fields map[string]any) (bool, error)
+	Move(id uint, position int) error
+}
+
+type walkInRepository struct {
+	db *gorm.DB
+}
+
+func NewWalkInRepository(db *gorm.DB) WalkInRepository {
+	return &walkInRepository{db: db}
+}
+
+// walkInQueueLockKey — ключ advisory-блокировки живой очереди.
+const walkInQueueLockKey = 7_240_001
+
+// lockQueue блокирует очередь до конца транзакции. Блокировка строк FOR UPDATE не подходит:
+// при пустой очереди блокировать нечего, и два прихода получили бы одну позицию.
+func lockQueue(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", walkInQueueLockKey).Error
+}
+
+// Create ставит клиента в конец очереди. Позиция и повторный приход проверяются под блокировкой очереди,
+// чтобы два одновременных прихода не получили один номер, а один клиент не встал в очередь дважды.
+// false означает, что клиент уже ждёт в очереди.
+func (r *walkInRepository) Create(entry *models.WalkInEntry) (bool, error) {
+	if entry == nil {
+		return false, nil
+	}
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockQueue(tx); err != nil {
+			return err
+		}
+
+		var waiting []models.WalkInEntry
+		if err := tx.Where("status = ?", models.WalkInWaiting).Find(&waiting).Error; err != nil {
+			return err
+		}
+
+		entry.Position = 1
+		for _, other := range waiting {
+			if other.ClientID == entry.ClientID {
+				return nil
+			}
+			if other.Position >= entry.Position {
+				entry.Position = other.Position + 1
+			}
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+func (r *walkInRepository) GetByID(id uint) (*models.WalkInEntry, error) {
+	var entry models.WalkInEntry
+
+	if err := r.db.Preload("Services").First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetWaiting возвращает ожидающих клиентов в порядке очереди.
+func (r *walkInRepository) GetWaiting() ([]models.WalkInEntry, error) {
+	var entries []models.WalkInEntry
+
+	if err := r.db.Preload("Services").Where("status = ?", models.WalkInWaiting).
+		Order("position, id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// UpdateStatus обновляет запись очереди, только если её статус всё ещё равен from.
+func (r *walkInRepository) UpdateStatus(id uint, from string, fields map[string]any) (bool, error) {
+	result := r.db.Model(&models.WalkInEntry{}).Where("id = ? AND status = ?", id, from).Updates(fields)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Move ставит ожидающего клиента на позицию position (с 1) и перенумеровывает остальных.
+// Позиция больше длины очереди означает конец очереди.
+func (r *walkInRepository) Move(id uint, position int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockQueue(tx); err != nil {
+			return err
+		}
+
+		var waiting []models.WalkInEntry
+		if err := tx.Where("status = ?", models.WalkInWaiting).Order("position, id").Find(&waiting).Error; err != nil {
+			return err
+		}
+
+		order := make([]uint, 0, len(waiting))
+		found := false
+		for _, entry := range waiting {
+			if entry.ID == id {
+				found = true
+				continue
+			}
+			order = append(order, entry.ID)
+		}
+		if !found {
+			return gorm.ErrRecordNotFound
+		}
+
+		if position > len(order)+1 {
+			position = len(order) + 1
+		}
+		order = append(order[:position-1], append([]uint{id}, order[position-1:]...)...)
+
+		for i, entryID := range order {
+			if err := tx.Model(&models.WalkInEntry{}).Where("id = ?", entryID).Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidTransition = errors.New("недопустимая смена статуса записи")
+	ErrStatusChanged     = errors.New("статус записи уже изменён, обновите данные")
+)
+
+// appointmentTransitions — из какого статуса в какие можно перевести запись.
+// completed, cancelled и no_show конечные.
+var appointmentTransitions = map[string][]string{
+	models.AppointmentPendingApproval: {models.AppointmentBooked, models.AppointmentCancelled},
+	models.AppointmentBooked:          {models.AppointmentConfirmed, models.AppointmentCheckedIn, models.AppointmentCancelled, models.AppointmentNoShow},
+	models.AppointmentConfirmed:       {models.AppointmentCheckedIn, models.AppointmentCancelled, models.AppointmentNoShow},
+	models.AppointmentCheckedIn:       {models.AppointmentCompleted},
+}
+
+func canTransition(from, to string) bool {
+	for _, allowed := range appointmentTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *appointmentsService) ChangeStatus(id uint, to string) (*models.Appointments, error) {
+	var before models.Appointments
+	err := s.service.Transaction(func(tx repository.AppointmentsRepository) error {
+		appointment, err := tx.GetByID(id)
+		if err != nil {
+			return err
+		}
+		before = *appointment
+
+		if !canTransition(appointment.Status, to) {
+			return fmt.Errorf("%w: %s → %s", ErrInvalidTransition, appointment.Status, to)
+		}
+		if to == models.AppointmentNoShow && s.calendar.now().Before(appointment.StartsAt) {
+			return errors.New("неявку можно отметить только после начала записи")
+		}
+
+		// Штраф считается до смены статуса: отмена неподтверждённой записи бесплатна.
+		outcome := evaluateCancellation(s.policy, appointment, to, s.calendar.now())
+		if err := s.setStatus(tx, appointment, to); err != nil {
+			return err
+		}
+
+		if to == models.AppointmentCancelled || to == models.AppointmentNoShow {
+			if err := tx.SetCancellation(appointment.ID, outcome.fee, outcome.reason); err != nil {
+				return err
+			}
+		}
+		if to == models.AppointmentNoShow {
+			return s.trackNoShow(tx, appointment.ClientID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if to == models.AppointmentCancelled {
+		s.waitlist.OfferFreedSlot(before.BarberID, before.StartsAt, before.EndsAt)
+	}
+
+	appointment, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	eventType := events.AppointmentUpdated
+	if to == models.AppointmentCancelled {
+		eventType = events.AppointmentCancelled
+	}
+	publishAppointment(s.events, eventType, appointment)
+	return appointment, nil
+}
+
+// Approve подтверждает запись помеченного клиента, после чего она становится обычной.
+func (s *appointmentsService) Approve(id uint) (*models.Appointments, error) {
+	return s.ChangeStatus(id, models.AppointmentBooked)
+}
+
+// Cancel отменяет запись по правилам отмены; штраф и его причина сохраняются в записи.
+func (s *appointmentsService) Cancel(id uint) (*models.Appointments, error) {
+	return s.ChangeStatus(id, models.AppointmentCancelled)
+}
+
+// setStatus меняет статус записи и пишет переход в историю; вызывается внутри транзакции.
+func (s *appointmentsService) setStatus(tx repository.AppointmentsRepository, appointment *models.Appointments, to string) error {
+	updated, err := tx.UpdateStatus(appointment.ID, appointment.Status, to)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrStatusChanged
+	}
+
+	change := models.AppointmentStatusChange{
+		AppointmentID: appointment.ID,
+		From:          appointment.Status,
+		To:            to,
+		ChangedAt:     s.calendar.now(),
+	}
+	if err := tx.AddStatusChange(&change); err != nil {
+		return err
+	}
+
+	appointment.Status = to
+	return nil
+}
@@ -1,111 +1,277 @@
 package service
 
 import (
+	"barber-backend-api/internal/events"
 	"barber-backend-api/internal/models"
 	"barber-backend-api/repository"
 	"errors"
+	"fmt"
 	"time"
 )
 
+var ErrSlotTaken = errors.New("это время занято другими")
+
+// bookNextAttempts — сколько раз BookNextAvailable повторяет поиск, если найденный слот успели занять.
+const bookNextAttempts = 3
+
 type AppointmentsService interface {
 	GetAllAppointments() ([]models.Appointments, error)
-	CreateAppointment(req *models.AppointmentsCreateDTO) error
-	Update(barberID uint, req models.AppointmentsUpdateReqDTO) error
+	CreateAppointment(req *models.AppointmentsCreateDTO) (*models.Appointments, error)
+	BookNextAvailable(req *models.AppointmentsNextAvailableReqDTO) (*models.Appointments, error)
+	ChangeStatus(id uint, status string) (*models.Appointments, error)
+	Cancel(id uint) (*models.Appointments, error)
+	Approve(id uint) (*models.Appointments, error)
+	Reschedule(id uint, req *models.AppointmentsRescheduleReqDTO) (*models.Appointments, error)
+	CreateSeries(req *models.AppointmentsCreateDTO) (*models.SeriesResDTO, error)
+	GetSeries(id uint) (*models.SeriesResDTO, error)
+	CancelSeries(id uint, scope string) (*models.SeriesResDTO, error)
+	RescheduleSeries(id uint, scope string, req *models.AppointmentsRescheduleReqDTO) (*models.SeriesResDTO, error)
 	Delete(id uint) error
 	GetAllAppointmentsByBarberID(id uint) ([]models.Appointments, error)
 	GetByID(id uint) (*models.Appointments, error)
 }
 
 type appointmentsService struct {
-	service repository.AppointmentsRepository
-	barber  repository.BarbersRepository
+	service  repository.AppointmentsRepository
+	barber   repository.BarbersRepository
+	services repository.BarberServicesRepository
+	clients  repository.ClientsRepository
+	calendar *WorkCalendar
+	policy   models.CancellationPolicy
+	noShows  models.NoShowRule
+	waitlist WaitlistService
+	events   *events.Bus
 }
 
-func NewAppointmentsService(service repository.AppointmentsRepository, barber repository.BarbersRepository) AppointmentsService {
-	return &appointmentsService{service: service, barber: barber}
+func NewAppointmentsService(service repository.AppointmentsRepository, barber repository.BarbersRepository, services repository.BarberServicesRepository, clients repository.ClientsRepository, calendar *WorkCalendar, policy models.CancellationPolicy, noShows models.NoShowRule, waitlist WaitlistService, bus *events.Bus) AppointmentsService {
+	return &appointmentsService{service: service, barber: barber, services: services, clients: clients, calendar: calendar, policy: policy, noShows: noShows, waitlist: waitlist, events: bus}
 }
 
 func (s *appointmentsService) GetAllAppointments() ([]models.Appointments, error) {
-	return s.service.GetAllAppointments()
+	appointments, err := s.service.GetAllAppointments()
+	if err != nil {
+		return nil, err
+	}
+	return s.calendar.localizeAll(appointments), nil
 }
 
-func (s *appointmentsService) CreateAppointment(req *models.AppointmentsCreateDTO) error {
-	listAppmts, err := s.service.GetAllAppointmentsByBarberID(req.BarberID)
+// CreateAppointment создаёт запись. Для клиента, помеченного за неявки, запись либо отклоняется,
+// либо создаётся в статусе pending_approval — это зависит от правила неявок.
+func (s *appointmentsService) CreateAppointment(req *models.AppointmentsCreateDTO) (*models.Appointments, error) {
+	t, err := parseAppointmentStart(req, s.calendar.loc)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	status, err := s.initialStatus(req.ClientID)
+	if err != nil {
+		return nil, err
 	}
-	date := req.Time
-	t, err := time.Parse("2006-01-02 15", date)
+
+	barber, slot, booked, err := planSlot(s.barber, s.services, s.calendar, req.BarberID, t, req.ServiceIDs)
 	if err != nil {
-		return errors.New("неправильный формат времени, нужен YYYY-MM-DD HH")
+		return nil, err
 	}
 
-	if t.Minute() != 0 || t.Second() != 0 {
-		t = t.Add(time.Hour).Truncate(time.Hour)
+	requestInput := models.Appointments{
+		BarberID: req.BarberID,
+		ClientID: req.ClientID,
+		StartsAt: slot.start,
+		EndsAt:   slot.end,
+		Services: booked.services,
+		Price:    booked.price,
+
+		BufferMinutes: int(booked.bufferFor(barber) / time.Minute),
+		Status:        status,
 	}
 
-	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
-		return errors.New("нельзя записаться на выходной день")
+	if err := s.book(&requestInput, slot); err != nil {
+		return nil, err
 	}
+	return &requestInput, nil
+}
 
-	if t.Before(time.Now()) {
-		return errors.New("запись просрочена")
+// book проверяет занятость слота и создаёт запись в одной транзакции,
+// удерживая блокировку парикмахера, поэтому параллельные запросы не займут один слот дважды.
+func (s *appointmentsService) book(appointment *models.Appointments, slot timeRange) error {
+	err := s.service.Transaction(func(tx repository.AppointmentsRepository) error {
+		return s.bookIn(tx, appointment, slot)
+	})
+	if err != nil {
+		return err
 	}
 
-	if t.Hour() < 9 || t.Hour() >= 17 {
-		return errors.New("парикмахер работает с 9 до 17 часов")
+	publishAppointment(s.events, events.AppointmentCreated, appointment)
+	return nil
+}
+
+// bookIn создаёт запись внутри уже открытой транзакции tx под блокировкой парикмахера.
+// Пауза после записи тоже должна быть свободна, а слот не должен быть забронирован.
+func (s *appointmentsService) bookIn(tx repository.AppointmentsRepository, appointment *models.Appointments, slot timeRange) error {
+	if err := tx.LockBarber(appointment.BarberID); err != nil {
+		return err
 	}
 
-	for _, v := range listAppmts {
-		vt, err := time.Parse(time.DateTime, v.Time)
+	now := s.calendar.now()
+	occupiedUntil := slot.end.Add(time.Duration(appointment.BufferMinutes) * time.Minute)
+	isFree, err := slotIsFree(tx, appointment.BarberID, slot.start, occupiedUntil, now, 0, 0)
+	if err != nil {
+		return err
+	}
+	if !isFree {
+		return ErrSlotTaken
+	}
+
+	return createAppointment(tx, appointment, now)
+}
+
+func (s *appointmentsService) BookNextAvailable(req *models.AppointmentsNextAvailableReqDTO) (*models.Appointments, error) {
+	after, err := parseAfter(req.After, s.calendar.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.initialStatus(req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < bookNextAttempts; attempt++ {
+		candidate, free, _, err := nextFreeSlot(s.service, s.barber, s.services, s.calendar, after, req.MinRating, req.ServiceIDs)
 		if err != nil {
+			return nil, err
+		}
+		// Найденный слот проходит те же проверки рабочего времени, что и обычная запись.
+		barber, slot, booked, err := planSlot(s.barber, s.services, s.calendar, candidate.ID, free.start, req.ServiceIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		appointment := models.Appointments{
+			BarberID: barber.ID,
+			ClientID: req.ClientID,
+			StartsAt: slot.start,
+			EndsAt:   slot.end,
+			Services: booked.services,
+			Price:    booked.price,
+
+			BufferMinutes: int(booked.bufferFor(barber) / time.Minute),
+			Status:        status,
+		}
+		err = s.book(&appointment, slot)
+		if errors.Is(err, ErrSlotTaken) {
 			continue
 		}
-		existingEnd := vt.Add(time.Hour)
-		newEnd := t.Add(time.Hour)
-		if vt.Before(newEnd) && existingEnd.After(t) {
-			return errors.New("это время занято другими")
+		if err != nil {
+			return nil, err
 		}
-	}
-	req.Time = t.Format(time.DateTime)
 
-	requestInput := models.Appointments{
-		BarberID: req.BarberID,
-		ClientID: req.ClientID,
-		Time:     req.Time,
+		return s.GetByID(appointment.ID)
 	}
 
-	if err := s.service.CreateAppointment(&requestInput); err != nil {
-		return err
-	}
-	return nil
+	return nil, ErrSlotTaken
 }
 
-func (s *appointmentsService) Update(id uint, req models.AppointmentsUpdateReqDTO) error {
-	lastAppointments, err := s.service.GetLastAppointments(req.BarberID)
+// Reschedule переносит запись на другое время и, если указан, к другому парикмахеру.
+// Проверки те же, что при создании; своё прежнее время запись не блокирует.
+func (s *appointmentsService) Reschedule(id uint, req *models.AppointmentsRescheduleReqDTO) (*models.Appointments, error) {
+	start, err := parseStartsAt(req.StartsAt, s.calendar.loc)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	current, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, err
 	}
 
-	if *lastAppointments.Rating != 0 {
-		return errors.New("вы уже ставили оценку")
+	barberID := current.BarberID
+	if req.BarberID != nil {
+		barberID = *req.BarberID
+	}
+	if err := s.move(current, barberID, start); err != nil {
+		return nil, err
 	}
 
-	startVisit, _ := time.Parse("2006-01-02 15", lastAppointments.Time)
-	endVisit := startVisit.Add(time.Hour)
+	return s.GetByID(id)
+}
+
+// move переносит одну запись в одной транзакции с блокировкой нового парикмахера
+// и сохраняет прежнее время в истории переносов.
+func (s *appointmentsService) move(current *models.Appointments, barberID uint, start time.Time) error {
+	id := current.ID
+	if current.Status != models.AppointmentBooked && current.Status != models.AppointmentConfirmed {
+		return fmt.Errorf("%w: нельзя перенести запись в статусе %s", ErrInvalidTransition, current.Status)
+	}
 
-	if endVisit.After(time.Now()) {
-		return errors.New("оценку можно ставить после услуги")
+	serviceIDs := make([]uint, 0, len(current.Services))
+	for _, svc := range current.Services {
+		serviceIDs = append(serviceIDs, svc.ID)
 	}
 
-	if err := s.service.Update(lastAppointments.ID, req); err != nil {
+	barber, slot, booked, err := planSlot(s.barber, s.services, s.calendar, barberID, start, serviceIDs)
+	if err != nil {
 		return err
 	}
+	buffer := booked.bufferFor(barber)
+
+	var moved *models.Appointments
+	err = s.service.Transaction(func(tx repository.AppointmentsRepository) error {
+		if err := tx.LockBarber(barberID); err != nil {
+			return err
+		}
+
+		appointment, err := tx.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if appointment.Status != current.Status || !appointment.StartsAt.Equal(current.StartsAt) || appointment.BarberID != current.BarberID {
+			return ErrStatusChanged
+		}
+
+		isFree, err := slotIsFree(tx, barberID, slot.start, slot.end.Add(buffer), s.calendar.now(), id, 0)
+		if err != nil {
+			return err
+		}
+		if !isFree {
+			return ErrSlotTaken
+		}
+
+		reschedule := models.AppointmentReschedule{
+			AppointmentID: id,
+			FromBarberID:  appointment.BarberID,
+			FromStartsAt:  appointment.StartsAt,
+			FromEndsAt:    appointment.EndsAt,
+			ToBarberID:    barberID,
+			ToStartsAt:    slot.start,
+			ToEndsAt:      slot.end,
+			RescheduledAt: s.calendar.now(),
+		}
 
-	if err := s.ratingBarbers(req.BarberID); err != nil {
+		appointment.BarberID = barberID
+		appointment.StartsAt = slot.start
+		appointment.EndsAt = slot.end
+		appointment.Services = booked.services
+		appointment.Price = booked.price
+		appointment.BufferMinutes = int(buffer / time.Minute)
+		if err := tx.Reschedule(appointment); err != nil {
+			return err
+		}
+		moved = appointment
+
+		return tx.AddReschedule(&reschedule)
+	})
+	if err != nil {
 		return err
 	}
 
+	if current.BarberID != barberID {
+		// Для экрана прежнего парикмахера запись ушла из его расписания. Событие публикуется
+		// раньше обновления, чтобы общий экран в итоге показал запись у нового парикмахера.
+		publishAppointment(s.events, events.AppointmentCancelled, current)
+	}
+	publishAppointment(s.events, events.AppointmentUpdated, moved)
+	s.waitlist.OfferFreedSlot(current.BarberID, current.StartsAt, current.EndsAt)
 	return nil
 }
 
@@ -115,55 +281,79 @@ func (s *appointmentsService) Delete(id uint) error {
 		return err
 	}
 
-	startVisit, _ := time.Parse("2006-01-02 15", appointment.Time)
-	endVisit := startVisit.Add(time.Hour)
-
-	if endVisit.After(time.Now()) {
+	// Удалить можно только запись, которую правила позволяют отменить бесплатно,
+	// или уже отменённую без штрафа; иначе штраф потерялся бы вместе с записью.
+	switch appointment.Status {
+	case models.AppointmentBooked, models.AppointmentConfirmed, models.AppointmentPendingApproval:
+		outcome := evaluateCancellation(s.policy, appointment, models.AppointmentCancelled, s.calendar.now())
+		if outcome.fee > 0 {
+			return fmt.Errorf("бесплатная отмена уже недоступна (%s), используйте отмену записи", outcome.reason)
+		}
+	case models.AppointmentCancelled:
+		if appointment.CancellationFee > 0 {
+			return errors.New("нельзя удалить отменённую запись со штрафом")
+		}
+	default:
 		return errors.New("невозможно удалить запись после предоставления услуги ")
 	}
 
 	if err := s.service.Delete(appointment.ID); err != nil {
 		return err
 	}
+
+	if appointment.Status != models.AppointmentCancelled {
+		publishAppointment(s.events, events.AppointmentCancelled, appointment)
+		s.waitlist.OfferFreedSlot(appointment.BarberID, appointment.StartsAt, appointment.EndsAt)
+	}
 	return nil
 }
 
 func (s *appointmentsService) GetAllAppointmentsByBarberID(id uint) ([]models.Appointments, error) {
-	return s.service.GetAllAppointmentsByBarberID(id)
+	appointments, err := s.service.GetAllAppointmentsByBarberID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.calendar.localizeAll(appointments), nil
 }
 
 func (s *appointmentsService) GetByID(id uint) (*models.Appointments, error) {
-	return s.service.GetByID(id)
-}
-
-func (s *appointmentsService) ratingBarbers(barberID uint) error {
-	appointments, err := s.service.GetAllAppointmentsByBarberID(barberID)
+	appointment, err := s.service.GetByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	s.calendar.localize(appointment)
+	return appointment, nil
+}
 
-	sum := 0
-	count := 0
+// parseAppointmentStart разбирает время начала записи из starts_at (RFC 3339 или "YYYY-MM-DD HH:MM")
+// либо из устаревшего поля time в формате "YYYY-MM-DD HH" или "YYYY-MM-DD HH:MM".
+func parseAppointmentStart(req *models.AppointmentsCreateDTO, loc *time.Location) (time.Time, error) {
+	if req.StartsAt != "" {
+		return parseStartsAt(req.StartsAt, loc)
+	}
 
-	for _, v := range appointments {
-		if v.Rating != nil {
-			sum += *v.Rating
-			count++
+	if req.Time != "" {
+		if t, err := time.ParseInLocation("2006-01-02 15:04", req.Time, loc); err == nil {
+			return t, nil
+		}
+		t, err := time.ParseInLocation("2006-01-02 15", req.Time, loc)
+		if err != nil {
+			return time.Time{}, errors.New("неправильный формат времени, нужен YYYY-MM-DD HH или YYYY-MM-DD HH:MM")
 		}
+		return t, nil
 	}
 
-	avg_rating := 0.0
-	if count == 0 {
-		avg_rating = 0
-	} else {
-		avg_rating = float64(sum) / float64(count)
-	}
-	ratingInput := models.Barber{
-		AvgRating: avg_rating,
-	}
+	return time.Time{}, errors.New("не указано время записи starts_at")
+}
 
-	if err := s.barber.Update(barberID, ratingInput); err != nil {
-		return err
+// parseStartsAt разбирает starts_at в формате RFC 3339 или "YYYY-MM-DD HH:MM" по часовому поясу салона.
+func parseStartsAt(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(loc), nil
 	}
-	return nil
+	t, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	if err != nil {
+		return time.Time{}, errors.New("неправильный формат starts_at, нужен RFC 3339 или YYYY-MM-DD HH:MM")
+	}
+	return t, nil
 }
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testModels — таблицы, которые тест пересоздаёт в тестовой базе.
+var testModels = []any{&models.Appointments{}, &models.Barber{}, &models.Client{}, &models.BarberSchedule{}, &models.ScheduleInterval{}, &models.TimeOff{}, &models.Holiday{}, &models.Service{}, &models.BarberServiceLink{}, &models.AppointmentStatusChange{}, &models.AppointmentReschedule{}, &models.SlotHold{}, &models.WaitlistEntry{}, &models.AppointmentSeries{}, &models.WalkInEntry{}, &models.Review{}, &models.BarberRatingStats{}}
+
+// testJoinTables — таблицы связей many2many: AutoMigrate создаёт их сам, но удалять нужно явно.
+var testJoinTables = []any{"appointment_services", "waitlist_entry_services", "slot_hold_services", "walk_in_entry_services"}
+
+// openTestDB подключается к Postgres из TEST_DATABASE_URL и пересоздаёт таблицы.
+// База должна быть отдельной, тестовой: её данные удаляются. Без переменной тест пропускается.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL не задан, тест с Postgres пропущен")
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  dsn,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("подключение к тестовой базе: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if err := db.Migrator().DropTable(append(testJoinTables, testModels...)...); err != nil {
+		t.Fatalf("удаление таблиц: %v", err)
+	}
+	if err := db.AutoMigrate(testModels...); err != nil {
+		t.Fatalf("миграция тестовой базы: %v", err)
+	}
+	return db
+}
+
+// nextWeekday возвращает ближайший после недели вперёд день недели weekday в hour:00 по часовому поясу loc.
+func nextWeekday(loc *time.Location, weekday time.Weekday, hour int) time.Time {
+	day := time.Now().In(loc).AddDate(0, 0, 7)
+	for day.Weekday() != weekday {
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, loc)
+}
+
+// TestCreateAppointmentConcurrentSameSlot проверяет блокировку парикмахера на настоящей базе:
+// из одновременных записей на один слот проходит ровно одна.
+func TestCreateAppointmentConcurrentSameSlot(t *testing.T) {
+	const attempts = 10
+
+	db := openTestDB(t)
+
+	barber := models.Barber{FullName: "Тестовый парикмахер", WorkHoursStart: 9, WorkHoursEnd: 17}
+	if err := db.Create(&barber).Error; err != nil {
+		t.Fatal(err)
+	}
+	clients := make([]models.Client, attempts)
+	for i := range clients {
+		clients[i].FullName = "Тестовый клиент"
+		if err := db.Create(&clients[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calendar := NewWorkCalendar(time.UTC, time.Hour, repository.NewSchedulesRepository(db), repository.NewTimeOffRepository(db), repository.NewHolidaysRepository(db))
+	svc := NewAppointmentsService(repository.NewAppointmentsRepository(db), repository.NewBarbersRepository(slog.Default(), db), repository.NewBarberServicesRepository(db), repository.NewClientsRepository(db), calendar, models.CancellationPolicy{}, models.NoShowRule{}, nil, nil)
+
+	slot := nextWeekday(time.UTC, time.Monday, 10).Format("2006-01-02 15")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	start := make(chan struct{})
+	for i := range clients {
+		wg.Add(1)
+		go func(clientID uint) {
+			defer wg.Done()
+			<-start
+			_, err := svc.CreateAppointment(&models.AppointmentsCreateDTO{BarberID: barber.ID, ClientID: clientID, Time: slot})
+			errs <- err
+		}(clients[i].ID)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	var succeeded, taken int
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, ErrSlotTaken):
+			taken++
+		default:
+			t.Errorf("неожиданная ошибка: %v", err)
+		}
+	}
+	if succeeded != 1 || taken != attempts-1 {
+		t.Fatalf("успешно %d, занято %d; ожидалось 1 и %d", succeeded, taken, attempts-1)
+	}
+
+	var stored int64
+	if err := db.Model(&models.Appointments{}).Where("barber_id = ?", barber.ID).Count(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored != 1 {
+		t.Fatalf("сохранено записей: %d, ожидалась одна", stored)
+	}
+}
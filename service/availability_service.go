@@ -0,0 +1,259 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"time"
+)
+
+const (
+	maxAvailabilityDays = 31
+	nextSlotSearchDays  = 14
+)
+
+type AvailabilityService interface {
+	GetBarberAvailability(barberID uint, from, to string, serviceIDs []uint) (*models.BarberAvailabilityDTO, error)
+	GetDayAvailability(date string, serviceIDs []uint) ([]models.BarberAvailabilityDTO, error)
+	GetNextAvailable(after string, minRating float64, serviceIDs []uint) (*models.NextSlotDTO, error)
+}
+
+type availabilityService struct {
+	appointments repository.AppointmentsRepository
+	barbers      repository.BarbersRepository
+	services     repository.BarberServicesRepository
+	calendar     *WorkCalendar
+}
+
+func NewAvailabilityService(appointments repository.AppointmentsRepository, barbers repository.BarbersRepository, services repository.BarberServicesRepository, calendar *WorkCalendar) AvailabilityService {
+	return &availabilityService{appointments: appointments, barbers: barbers, services: services, calendar: calendar}
+}
+
+func (s *availabilityService) GetBarberAvailability(barberID uint, from, to string, serviceIDs []uint) (*models.BarberAvailabilityDTO, error) {
+	if from == "" {
+		from = s.calendar.now().Format(time.DateOnly)
+	}
+	fromDate, err := time.ParseInLocation(time.DateOnly, from, s.calendar.loc)
+	if err != nil {
+		return nil, errors.New("неправильный формат даты from, нужен YYYY-MM-DD")
+	}
+
+	toDate := fromDate.AddDate(0, 0, 6)
+	if to != "" {
+		toDate, err = time.ParseInLocation(time.DateOnly, to, s.calendar.loc)
+		if err != nil {
+			return nil, errors.New("неправильный формат даты to, нужен YYYY-MM-DD")
+		}
+	}
+
+	if toDate.Before(fromDate) {
+		return nil, errors.New("дата to не может быть раньше from")
+	}
+	if !toDate.Before(fromDate.AddDate(0, 0, maxAvailabilityDays)) {
+		return nil, errors.New("период поиска не может превышать 31 день")
+	}
+
+	barber, err := s.barbers.GetBarberByID(barberID)
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := resolveServices(s.services, barberID, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.barberAvailability(barber, fromDate, toDate, booked)
+}
+
+func (s *availabilityService) GetDayAvailability(date string, serviceIDs []uint) ([]models.BarberAvailabilityDTO, error) {
+	if date == "" {
+		date = s.calendar.now().Format(time.DateOnly)
+	}
+	day, err := time.ParseInLocation(time.DateOnly, date, s.calendar.loc)
+	if err != nil {
+		return nil, errors.New("неправильный формат даты, нужен YYYY-MM-DD")
+	}
+
+	barbers, err := s.barbers.GetAllBarbers()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.BarberAvailabilityDTO, 0, len(barbers))
+	for i := range barbers {
+		booked, err := resolveServices(s.services, barbers[i].ID, serviceIDs)
+		if errors.Is(err, ErrServiceNotOffered) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		availability, err := s.barberAvailability(&barbers[i], day, day, booked)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *availability)
+	}
+	return res, nil
+}
+
+func (s *availabilityService) GetNextAvailable(after string, minRating float64, serviceIDs []uint) (*models.NextSlotDTO, error) {
+	afterTime, err := parseAfter(after, s.calendar.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	barber, slot, _, err := nextFreeSlot(s.appointments, s.barbers, s.services, s.calendar, afterTime, minRating, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.NextSlotDTO{
+		BarberID:  barber.ID,
+		FullName:  barber.FullName,
+		AvgRating: barber.AvgRating,
+		Start:     slot.start.Format(time.RFC3339),
+		End:       slot.end.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *availabilityService) barberAvailability(barber *models.Barber, from, to time.Time, booked *bookingServices) (*models.BarberAvailabilityDTO, error) {
+	busy, err := occupiedRanges(s.appointments, barber.ID, from, to.AddDate(0, 0, 1), s.calendar.now())
+	if err != nil {
+		return nil, err
+	}
+
+	res := models.BarberAvailabilityDTO{
+		BarberID: barber.ID,
+		FullName: barber.FullName,
+		Days:     []models.DayAvailabilityDTO{},
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		slots, err := s.calendar.freeSlots(barber, day, busy, booked.duration, booked.bufferFor(barber), s.calendar.now())
+		if err != nil {
+			return nil, err
+		}
+
+		dayRes := models.DayAvailabilityDTO{
+			Date:  day.Format(time.DateOnly),
+			Slots: make([]models.SlotDTO, 0, len(slots)),
+		}
+		for _, slot := range slots {
+			dayRes.Slots = append(dayRes.Slots, models.SlotDTO{
+				Start: slot.start.Format(time.RFC3339),
+				End:   slot.end.Format(time.RFC3339),
+			})
+		}
+		res.Days = append(res.Days, dayRes)
+	}
+
+	return &res, nil
+}
+
+// nextFreeSlot ищет самый ранний свободный слот среди всех парикмахеров с рейтингом не ниже minRating,
+// которые оказывают выбранные услуги. При одинаковом времени предпочтение отдаётся парикмахеру
+// с более высоким рейтингом.
+func nextFreeSlot(appointments repository.AppointmentsRepository, barbersRepo repository.BarbersRepository, links repository.BarberServicesRepository, calendar *WorkCalendar, after time.Time, minRating float64, serviceIDs []uint) (*models.Barber, timeRange, *bookingServices, error) {
+	barbers, err := barbersRepo.GetAllBarbers()
+	if err != nil {
+		return nil, timeRange{}, nil, err
+	}
+
+	notBefore := after
+	if now := calendar.now(); notBefore.Before(now) {
+		notBefore = now
+	}
+	firstDay := calendar.startOfDay(notBefore)
+	lastDay := firstDay.AddDate(0, 0, nextSlotSearchDays)
+
+	busy := make(map[uint][]timeRange)
+	booked := make(map[uint]*bookingServices)
+	candidates := make([]models.Barber, 0, len(barbers))
+	for _, barber := range barbers {
+		if barber.AvgRating < minRating {
+			continue
+		}
+		services, err := resolveServices(links, barber.ID, serviceIDs)
+		if errors.Is(err, ErrServiceNotOffered) {
+			continue
+		}
+		if err != nil {
+			return nil, timeRange{}, nil, err
+		}
+		occupied, err := occupiedRanges(appointments, barber.ID, firstDay, lastDay, calendar.now())
+		if err != nil {
+			return nil, timeRange{}, nil, err
+		}
+		busy[barber.ID] = occupied
+		booked[barber.ID] = services
+		candidates = append(candidates, barber)
+	}
+
+	for day := firstDay; day.Before(lastDay); day = day.AddDate(0, 0, 1) {
+		var (
+			best     *models.Barber
+			bestSlot timeRange
+		)
+		for i := range candidates {
+			services := booked[candidates[i].ID]
+			slots, err := calendar.freeSlots(&candidates[i], day, busy[candidates[i].ID], services.duration, services.bufferFor(&candidates[i]), notBefore)
+			if err != nil {
+				return nil, timeRange{}, nil, err
+			}
+			if len(slots) == 0 {
+				continue
+			}
+			if best == nil || slots[0].start.Before(bestSlot.start) ||
+				(slots[0].start.Equal(bestSlot.start) && candidates[i].AvgRating > best.AvgRating) {
+				best = &candidates[i]
+				bestSlot = slots[0]
+			}
+		}
+		if best != nil {
+			return best, bestSlot, booked[best.ID], nil
+		}
+	}
+
+	return nil, timeRange{}, nil, errors.New("свободных слотов в ближайшие две недели нет")
+}
+
+// barberNextSlot ищет ближайший свободный слот одного парикмахера не раньше notBefore
+// в пределах nextSlotSearchDays; ok == false, если мест нет.
+func barberNextSlot(appointments repository.AppointmentsRepository, calendar *WorkCalendar, barber *models.Barber, booked *bookingServices, notBefore time.Time) (timeRange, bool, error) {
+	firstDay := calendar.startOfDay(notBefore)
+	lastDay := firstDay.AddDate(0, 0, nextSlotSearchDays)
+
+	busy, err := occupiedRanges(appointments, barber.ID, firstDay, lastDay, calendar.now())
+	if err != nil {
+		return timeRange{}, false, err
+	}
+
+	for day := firstDay; day.Before(lastDay); day = day.AddDate(0, 0, 1) {
+		slots, err := calendar.freeSlots(barber, day, busy, booked.duration, booked.bufferFor(barber), notBefore)
+		if err != nil {
+			return timeRange{}, false, err
+		}
+		if len(slots) > 0 {
+			return slots[0], true, nil
+		}
+	}
+	return timeRange{}, false, nil
+}
+
+// parseAfter разбирает момент, после которого ищется слот; пустая строка означает "сейчас".
+func parseAfter(after string, loc *time.Location) (time.Time, error) {
+	if after == "" {
+		return time.Now().In(loc), nil
+	}
+	if t, err := time.Parse(time.RFC3339, after); err == nil {
+		return t.In(loc), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04", after, loc)
+	if err != nil {
+		return time.Time{}, errors.New("неправильный формат времени after, нужен YYYY-MM-DD HH:MM")
+	}
+	return t, nil
+}
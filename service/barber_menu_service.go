@@ -0,0 +1,140 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"log/slog"
+)
+
+type BarberMenuService interface {
+	GetMenu(barberID uint) ([]models.BarberMenuItemDTO, error)
+	SetService(barberID, serviceID uint, req *models.BarberServiceReqDTO) (*models.BarberMenuItemDTO, error)
+	RemoveService(barberID, serviceID uint) error
+}
+
+type barberMenuService struct {
+	logger  *slog.Logger
+	service repository.BarberServicesRepository
+	barbers repository.BarbersRepository
+	catalog repository.ServicesRepository
+}
+
+func NewBarberMenuService(logger *slog.Logger, service repository.BarberServicesRepository, barbers repository.BarbersRepository, catalog repository.ServicesRepository) BarberMenuService {
+	return &barberMenuService{logger: logger, service: service, barbers: barbers, catalog: catalog}
+}
+
+func (s *barberMenuService) GetMenu(barberID uint) ([]models.BarberMenuItemDTO, error) {
+	isExist, err := s.barbers.Exists(barberID)
+	if err != nil {
+		s.logger.Error("ошибка проверки существования парикмахера",
+			"op", "service.barber_menu.GetMenu",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return nil, err
+	}
+	if !isExist {
+		return nil, errors.New("парикмахер не найден")
+	}
+
+	return barberMenu(s.service, barberID, false)
+}
+
+func (s *barberMenuService) SetService(barberID, serviceID uint, req *models.BarberServiceReqDTO) (*models.BarberMenuItemDTO, error) {
+	isExist, err := s.barbers.Exists(barberID)
+	if err != nil {
+		s.logger.Error("ошибка проверки существования парикмахера",
+			"op", "service.barber_menu.SetService",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return nil, err
+	}
+	if !isExist {
+		return nil, errors.New("парикмахер не найден")
+	}
+
+	svc, err := s.catalog.GetByID(serviceID)
+	if err != nil {
+		s.logger.Warn("услуга не найдена",
+			"op", "service.barber_menu.SetService",
+			"service_id", serviceID,
+			"error", err,
+		)
+		return nil, errors.New("услуга не найдена")
+	}
+
+	duration, price := svc.DurationMinutes, svc.Price
+	if req.DurationMinutes != nil {
+		duration = *req.DurationMinutes
+	}
+	if req.Price != nil {
+		price = *req.Price
+	}
+	if err := validateService(duration, price); err != nil {
+		return nil, err
+	}
+
+	link := models.BarberServiceLink{
+		BarberID:        barberID,
+		ServiceID:       serviceID,
+		Price:           req.Price,
+		DurationMinutes: req.DurationMinutes,
+	}
+	if err := s.service.Upsert(&link); err != nil {
+		s.logger.Error("ошибка сохранения услуги парикмахера",
+			"op", "service.barber_menu.SetService",
+			"barber_id", barberID,
+			"service_id", serviceID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	link.Service = *svc
+	item := menuItem(link)
+
+	s.logger.Info("услуга парикмахера сохранена",
+		"op", "service.barber_menu.SetService",
+		"barber_id", barberID,
+		"service_id", serviceID,
+	)
+	return &item, nil
+}
+
+func (s *barberMenuService) RemoveService(barberID, serviceID uint) error {
+	if err := s.service.Delete(barberID, serviceID); err != nil {
+		s.logger.Warn("ошибка удаления услуги парикмахера",
+			"op", "service.barber_menu.RemoveService",
+			"barber_id", barberID,
+			"service_id", serviceID,
+			"error", err,
+		)
+		return errors.New("парикмахер не оказывает эту услугу")
+	}
+
+	s.logger.Info("услуга убрана из меню парикмахера",
+		"op", "service.barber_menu.RemoveService",
+		"barber_id", barberID,
+		"service_id", serviceID,
+	)
+	return nil
+}
+
+// barberMenu собирает меню парикмахера с его ценами; activeOnly скрывает выключенные в каталоге услуги.
+func barberMenu(links repository.BarberServicesRepository, barberID uint, activeOnly bool) ([]models.BarberMenuItemDTO, error) {
+	offered, err := links.GetByBarberID(barberID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.BarberMenuItemDTO, 0, len(offered))
+	for _, link := range offered {
+		if activeOnly && !link.Service.Active {
+			continue
+		}
+		res = append(res, menuItem(link))
+	}
+	return res, nil
+}
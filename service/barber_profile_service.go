@@ -0,0 +1,132 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"time"
+)
+
+const (
+	defaultProfileReviews = 5
+	maxProfileReviews     = 20
+)
+
+type BarberProfileService interface {
+	GetProfile(barberID uint, reviews int) (*models.BarberProfileResDTO, error)
+}
+
+type barberProfileService struct {
+	barbers      repository.BarbersRepository
+	links        repository.BarberServicesRepository
+	schedules    repository.SchedulesRepository
+	reviews      repository.ReviewsRepository
+	appointments repository.AppointmentsRepository
+	calendar     *WorkCalendar
+	prior        models.RatingPrior
+}
+
+func NewBarberProfileService(barbers repository.BarbersRepository, links repository.BarberServicesRepository, schedules repository.SchedulesRepository, reviews repository.ReviewsRepository, appointments repository.AppointmentsRepository, calendar *WorkCalendar, prior models.RatingPrior) BarberProfileService {
+	return &barberProfileService{barbers: barbers, links: links, schedules: schedules, reviews: reviews, appointments: appointments, calendar: calendar, prior: prior}
+}
+
+// GetProfile собирает публичную карточку парикмахера. reviews — сколько последних опубликованных
+// отзывов показать (0 — по умолчанию 5, не больше 20).
+func (s *barberProfileService) GetProfile(barberID uint, reviews int) (*models.BarberProfileResDTO, error) {
+	switch {
+	case reviews == 0:
+		reviews = defaultProfileReviews
+	case reviews < 0 || reviews > maxProfileReviews:
+		return nil, errors.New("число отзывов должно быть от 1 до 20")
+	}
+
+	barber, err := s.barbers.GetBarberByID(barberID)
+	if err != nil {
+		return nil, errors.New("парикмахер не найден")
+	}
+
+	hours, err := s.workingHours(barber)
+	if err != nil {
+		return nil, err
+	}
+
+	menu, err := barberMenu(s.links, barber.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.reviews.GetRatingStats(barber.ID)
+	if err != nil {
+		return nil, err
+	}
+	globalSum, globalCount, err := s.reviews.GetGlobalRating()
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.reviews.GetPublishedByBarberID(barber.ID, reviews)
+	if err != nil {
+		return nil, err
+	}
+	public := make([]models.PublicReviewDTO, 0, len(latest))
+	for _, review := range latest {
+		public = append(public, models.PublicReviewDTO{
+			Rating:    review.Rating,
+			Text:      review.Text,
+			CreatedAt: review.CreatedAt,
+			Reply:     review.Reply,
+			RepliedAt: review.RepliedAt,
+		})
+	}
+
+	res := &models.BarberProfileResDTO{
+		ID:           barber.ID,
+		FullName:     barber.FullName,
+		WorkingHours: hours,
+		Services:     menu,
+		Rating:       ratingSummary(stats, globalSum, globalCount, s.prior),
+		ReviewCount:  stats.RatingCount,
+		Reviews:      public,
+	}
+
+	booked, err := resolveServices(s.links, barber.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	slot, ok, err := barberNextSlot(s.appointments, s.calendar, barber, booked, s.calendar.now())
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		res.NextAvailable = &models.SlotDTO{
+			Start: slot.start.Format(time.RFC3339),
+			End:   slot.end.Format(time.RFC3339),
+		}
+	}
+
+	return res, nil
+}
+
+// workingHours возвращает недельный график, действующий сегодня. Без графика парикмахер
+// работает по будням в часы из карточки — так же, как считает WorkCalendar.
+func (s *barberProfileService) workingHours(barber *models.Barber) (map[string][]models.ScheduleIntervalDTO, error) {
+	schedule, err := s.schedules.GetEffective(barber.ID, s.calendar.startOfDay(s.calendar.now()))
+	if err != nil {
+		return nil, err
+	}
+	if schedule != nil {
+		return scheduleToDTO(*schedule).Days, nil
+	}
+
+	hours := make(map[string][]models.ScheduleIntervalDTO)
+	for name, weekday := range weekdayNames {
+		if weekday == time.Saturday || weekday == time.Sunday {
+			continue
+		}
+		hours[name] = []models.ScheduleIntervalDTO{{
+			Start: formatClock(barber.WorkHoursStart * 60),
+			End:   formatClock(barber.WorkHoursEnd * 60),
+		}}
+	}
+	return hours, nil
+}
@@ -7,21 +7,27 @@ import (
 	"log/slog"
 )
 
+const (
+	defaultWorkHoursStart = 9
+	defaultWorkHoursEnd   = 17
+)
+
 type BarberService interface {
 	AddBarber(req *models.BarbersCreateReqDTO) error
 	Update(id uint, barberInp models.Barber) (*models.Barber, error)
-	GetAll() ([]models.BarberResDTO, error)
+	GetAll(includeServices bool) ([]models.BarberResDTO, error)
 	GetBarberByID(id uint) (*models.Barber, error)
 	Delete(id uint) error
 }
 
 type barberService struct {
-	logger  *slog.Logger
-	service repository.BarbersRepository
+	logger   *slog.Logger
+	service  repository.BarbersRepository
+	services repository.BarberServicesRepository
 }
 
-func NewBarbersService(logger *slog.Logger, service repository.BarbersRepository) BarberService {
-	return &barberService{logger: logger, service: service}
+func NewBarbersService(logger *slog.Logger, service repository.BarbersRepository, services repository.BarberServicesRepository) BarberService {
+	return &barberService{logger: logger, service: service, services: services}
 }
 
 func (s *barberService) AddBarber(req *models.BarbersCreateReqDTO) error {
@@ -36,7 +42,36 @@ func (s *barberService) AddBarber(req *models.BarbersCreateReqDTO) error {
 		return errors.New("empty req")
 	}
 	barber := models.Barber{
-		FullName: req.FullName,
+		FullName:       req.FullName,
+		WorkHoursStart: defaultWorkHoursStart,
+		WorkHoursEnd:   defaultWorkHoursEnd,
+	}
+	if req.WorkHoursStart != nil {
+		barber.WorkHoursStart = *req.WorkHoursStart
+	}
+	if req.WorkHoursEnd != nil {
+		barber.WorkHoursEnd = *req.WorkHoursEnd
+	}
+	if req.BufferMinutes != nil {
+		if err := validateBuffer(*req.BufferMinutes); err != nil {
+			s.logger.Error("некорректная пауза после записи",
+				"op", "service.barber.AddBarber",
+				"buffer_minutes", *req.BufferMinutes,
+				"error", err,
+			)
+			return err
+		}
+		barber.BufferMinutes = req.BufferMinutes
+	}
+
+	if err := validateWorkHours(barber.WorkHoursStart, barber.WorkHoursEnd); err != nil {
+		s.logger.Error("некорректные часы работы",
+			"op", "service.barber.AddBarber",
+			"work_hours_start", barber.WorkHoursStart,
+			"work_hours_end", barber.WorkHoursEnd,
+			"error", err,
+		)
+		return err
 	}
 
 	s.logger.Info("присвоено значение из DTO в доменную модель",
@@ -76,6 +111,49 @@ func (s *barberService) Update(id uint, barberInp models.Barber) (*models.Barber
 		return nil, errors.New("записи с таким ID не найдено")
 	}
 
+	if barberInp.WorkHoursStart != 0 || barberInp.WorkHoursEnd != 0 {
+		current, err := s.service.GetBarberByID(id)
+		if err != nil {
+			s.logger.Error("ошибка получения парикмахера для проверки часов работы",
+				"op", "service.barber.Update",
+				"id", id,
+				"error", err,
+			)
+			return nil, err
+		}
+
+		start, end := current.WorkHoursStart, current.WorkHoursEnd
+		if barberInp.WorkHoursStart != 0 {
+			start = barberInp.WorkHoursStart
+		}
+		if barberInp.WorkHoursEnd != 0 {
+			end = barberInp.WorkHoursEnd
+		}
+
+		if err := validateWorkHours(start, end); err != nil {
+			s.logger.Error("некорректные часы работы",
+				"op", "service.barber.Update",
+				"id", id,
+				"work_hours_start", start,
+				"work_hours_end", end,
+				"error", err,
+			)
+			return nil, err
+		}
+	}
+
+	if barberInp.BufferMinutes != nil {
+		if err := validateBuffer(*barberInp.BufferMinutes); err != nil {
+			s.logger.Error("некорректная пауза после записи",
+				"op", "service.barber.Update",
+				"id", id,
+				"buffer_minutes", *barberInp.BufferMinutes,
+				"error", err,
+			)
+			return nil, err
+		}
+	}
+
 	if err := s.service.Update(id, barberInp); err != nil {
 		s.logger.Error("ошибка обновления записи парикмахера",
 			"op", "service.barber.Update",
@@ -108,7 +186,7 @@ func (s *barberService) Update(id uint, barberInp models.Barber) (*models.Barber
 	return barber, nil
 }
 
-func (s *barberService) GetAll() ([]models.BarberResDTO, error) {
+func (s *barberService) GetAll(includeServices bool) ([]models.BarberResDTO, error) {
 	s.logger.Info("запуск функции получения всех парикмахеров", "op", "service.barber.GetAll")
 	res, err := s.service.GetAll()
 	if err != nil {
@@ -118,6 +196,21 @@ func (s *barberService) GetAll() ([]models.BarberResDTO, error) {
 		)
 		return nil, err
 	}
+
+	if includeServices {
+		for i := range res {
+			menu, err := barberMenu(s.services, res[i].ID, true)
+			if err != nil {
+				s.logger.Error("ошибка получения услуг парикмахера",
+					"op", "service.barber.GetAll",
+					"id", res[i].ID,
+					"error", err,
+				)
+				return nil, err
+			}
+			res[i].Services = menu
+		}
+	}
 	s.logger.Info("список парикмахеров получен успешно",
 		"op", "service.barber.GetAll",
 		"count", len(res),
@@ -194,3 +287,15 @@ func (s *barberService) Delete(id uint) error {
 
 	return nil
 }
+
+func validateWorkHours(start, end int) error {
+	// 0 не принимается: при создании GORM подставил бы вместо него значение по умолчанию,
+	// а при обновлении нулевое поле пропускается, поэтому полночь нельзя сохранить одинаково.
+	if start < 1 || start > 23 || end < 2 || end > 24 {
+		return errors.New("часы работы должны быть в диапазоне от 1 до 24")
+	}
+	if start >= end {
+		return errors.New("начало рабочего дня должно быть раньше его окончания")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"fmt"
+	"time"
+)
+
+type cancellationOutcome struct {
+	fee    int64
+	reason string
+}
+
+// evaluateCancellation считает штраф за отмену записи в момент now или за неявку.
+func evaluateCancellation(policy models.CancellationPolicy, appointment *models.Appointments, status string, now time.Time) cancellationOutcome {
+	if appointment.Status == models.AppointmentPendingApproval {
+		return cancellationOutcome{reason: "запись не была подтверждена администратором"}
+	}
+	if status == models.AppointmentNoShow {
+		return cancellationOutcome{
+			fee:    appointment.Price * int64(policy.NoShowFeePercent) / 100,
+			reason: fmt.Sprintf("неявка: штраф %d%%", policy.NoShowFeePercent),
+		}
+	}
+
+	left := appointment.StartsAt.Sub(now)
+	var applied *models.CancellationWindow
+	for i, window := range policy.Windows {
+		if left < window.Before && (applied == nil || window.Before < applied.Before) {
+			applied = &policy.Windows[i]
+		}
+	}
+	if applied == nil {
+		return cancellationOutcome{reason: "бесплатная отмена"}
+	}
+
+	return cancellationOutcome{
+		fee:    appointment.Price * int64(applied.FeePercent) / 100,
+		reason: fmt.Sprintf("отмена менее чем за %s до начала: штраф %d%%", formatWindow(applied.Before), applied.FeePercent),
+	}
+}
+
+// formatWindow выводит срок в часах, если он кратен часу, иначе в минутах.
+func formatWindow(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%d ч", d/time.Hour)
+	}
+	return fmt.Sprintf("%d мин", d/time.Minute)
+}
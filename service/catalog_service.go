@@ -0,0 +1,223 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultAppointmentDuration используется, если при записи не выбрано ни одной услуги.
+const defaultAppointmentDuration = time.Hour
+
+type CatalogService interface {
+	Create(req *models.ServiceCreateReqDTO) (*models.Service, error)
+	GetAll(activeOnly bool) ([]models.Service, error)
+	GetByID(id uint) (*models.Service, error)
+	Update(id uint, req *models.ServiceUpdateReqDTO) (*models.Service, error)
+	Delete(id uint) error
+}
+
+type catalogService struct {
+	service repository.ServicesRepository
+}
+
+func NewCatalogService(service repository.ServicesRepository) CatalogService {
+	return &catalogService{service: service}
+}
+
+func (s *catalogService) Create(req *models.ServiceCreateReqDTO) (*models.Service, error) {
+	if err := validateService(req.DurationMinutes, req.Price); err != nil {
+		return nil, err
+	}
+	if err := validateBuffer(req.BufferMinutes); err != nil {
+		return nil, err
+	}
+
+	res := models.Service{
+		Name:            req.Name,
+		DurationMinutes: req.DurationMinutes,
+		Price:           req.Price,
+		Active:          true,
+		BufferMinutes:   req.BufferMinutes,
+	}
+	if req.Active != nil {
+		res.Active = *req.Active
+	}
+
+	if err := s.service.Create(&res); err != nil {
+		return nil, err
+	}
+	// default:true в теге не даёт сохранить false при вставке, поэтому неактивную услугу выключаем отдельно
+	if !res.Active {
+		if err := s.service.Update(res.ID, map[string]any{"active": false}); err != nil {
+			return nil, err
+		}
+	}
+	return &res, nil
+}
+
+func (s *catalogService) GetAll(activeOnly bool) ([]models.Service, error) {
+	return s.service.GetAll(activeOnly)
+}
+
+func (s *catalogService) GetByID(id uint) (*models.Service, error) {
+	return s.service.GetByID(id)
+}
+
+func (s *catalogService) Update(id uint, req *models.ServiceUpdateReqDTO) (*models.Service, error) {
+	current, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	duration, price := current.DurationMinutes, current.Price
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, errors.New("название услуги не может быть пустым")
+		}
+		fields["name"] = *req.Name
+	}
+	if req.DurationMinutes != nil {
+		duration = *req.DurationMinutes
+		fields["duration_minutes"] = duration
+	}
+	if req.Price != nil {
+		price = *req.Price
+		fields["price"] = price
+	}
+	if req.Active != nil {
+		fields["active"] = *req.Active
+	}
+	if req.BufferMinutes != nil {
+		if err := validateBuffer(*req.BufferMinutes); err != nil {
+			return nil, err
+		}
+		fields["buffer_minutes"] = *req.BufferMinutes
+	}
+
+	if err := validateService(duration, price); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return current, nil
+	}
+
+	if err := s.service.Update(id, fields); err != nil {
+		return nil, err
+	}
+	return s.service.GetByID(id)
+}
+
+func (s *catalogService) Delete(id uint) error {
+	return s.service.Delete(id)
+}
+
+// maxBufferMinutes ограничивает паузу после записи двумя часами.
+const maxBufferMinutes = 120
+
+func validateBuffer(minutes int) error {
+	if minutes < 0 || minutes > maxBufferMinutes {
+		return errors.New("пауза после записи должна быть от 0 до 120 минут")
+	}
+	return nil
+}
+
+func validateService(durationMinutes int, price int64) error {
+	if durationMinutes <= 0 || durationMinutes > 8*60 {
+		return errors.New("длительность услуги должна быть от 1 до 480 минут")
+	}
+	if price < 0 {
+		return errors.New("цена услуги не может быть отрицательной")
+	}
+	return nil
+}
+
+var ErrServiceNotOffered = errors.New("парикмахер не оказывает эту услугу")
+
+type bookingServices struct {
+	services []models.Service
+	duration time.Duration
+	price    int64
+	// buffer — самая длинная пауза среди выбранных услуг.
+	buffer time.Duration
+}
+
+// bufferFor возвращает паузу после записи: большую из паузы парикмахера и паузы услуг.
+func (b *bookingServices) bufferFor(barber *models.Barber) time.Duration {
+	buffer := b.buffer
+	if barber.BufferMinutes != nil {
+		if own := time.Duration(*barber.BufferMinutes) * time.Minute; own > buffer {
+			buffer = own
+		}
+	}
+	return buffer
+}
+
+// resolveServices проверяет, что парикмахер оказывает выбранные услуги,
+// и считает общую длительность и цену записи с учётом его персональных цен.
+// Пустой список означает запись без услуг на defaultAppointmentDuration; повтор услуги — ошибка.
+func resolveServices(links repository.BarberServicesRepository, barberID uint, ids []uint) (*bookingServices, error) {
+	if len(ids) == 0 {
+		return &bookingServices{duration: defaultAppointmentDuration}, nil
+	}
+
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fmt.Errorf("услуга %d указана дважды", id)
+		}
+		seen[id] = true
+	}
+
+	offered, err := links.GetByBarberID(barberID)
+	if err != nil {
+		return nil, err
+	}
+
+	byServiceID := make(map[uint]models.BarberServiceLink, len(offered))
+	for _, link := range offered {
+		byServiceID[link.ServiceID] = link
+	}
+
+	res := &bookingServices{services: make([]models.Service, 0, len(ids))}
+	for _, id := range ids {
+		link, ok := byServiceID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrServiceNotOffered, id)
+		}
+		if !link.Service.Active {
+			return nil, fmt.Errorf("услуга %q сейчас недоступна", link.Service.Name)
+		}
+
+		item := menuItem(link)
+		res.duration += time.Duration(item.DurationMinutes) * time.Minute
+		res.price += item.Price
+		if buffer := time.Duration(link.Service.BufferMinutes) * time.Minute; buffer > res.buffer {
+			res.buffer = buffer
+		}
+		res.services = append(res.services, link.Service)
+	}
+
+	return res, nil
+}
+
+// menuItem возвращает услугу в меню парикмахера с учётом его цены и длительности.
+func menuItem(link models.BarberServiceLink) models.BarberMenuItemDTO {
+	item := models.BarberMenuItemDTO{
+		ServiceID:       link.ServiceID,
+		Name:            link.Service.Name,
+		DurationMinutes: link.Service.DurationMinutes,
+		Price:           link.Service.Price,
+		Active:          link.Service.Active,
+	}
+	if link.DurationMinutes != nil {
+		item.DurationMinutes = *link.DurationMinutes
+	}
+	if link.Price != nil {
+		item.Price = *link.Price
+	}
+	return item
+}
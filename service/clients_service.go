@@ -3,6 +3,7 @@ package service
 import (
 	"barber-backend-api/internal/models"
 	"barber-backend-api/repository"
+	"time"
 )
 
 type ClientService interface {
@@ -11,14 +12,19 @@ type ClientService interface {
 	GetAllClients() ([]models.ClientRespDTO, error)
 	Update(id uint, client models.ClientUpdateReqDTO) error
 	Delete(id uint) error
+	GetFlagged() ([]models.ClientNoShowsResDTO, error)
+	GetNoShows(id uint) (*models.ClientNoShowsResDTO, error)
+	ClearFlag(id uint) (*models.ClientNoShowsResDTO, error)
 }
 
 type clientService struct {
-	service repository.ClientsRepository
+	service      repository.ClientsRepository
+	appointments repository.AppointmentsRepository
+	rule         models.NoShowRule
 }
 
-func NewClientsService(service repository.ClientsRepository) ClientService {
-	return &clientService{service: service}
+func NewClientsService(service repository.ClientsRepository, appointments repository.AppointmentsRepository, rule models.NoShowRule) ClientService {
+	return &clientService{service: service, appointments: appointments, rule: rule}
 }
 
 func (s *clientService) AddClient(req *models.ClientCreateReqDTO) error {
@@ -53,3 +59,57 @@ func (s *clientService) Delete(id uint) error {
 	}
 	return s.service.Delete(client.ID)
 }
+
+func (s *clientService) GetFlagged() ([]models.ClientNoShowsResDTO, error) {
+	clients, err := s.service.GetFlagged()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.ClientNoShowsResDTO, 0, len(clients))
+	for i := range clients {
+		item, err := s.noShowsDTO(&clients[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *item)
+	}
+	return res, nil
+}
+
+func (s *clientService) GetNoShows(id uint) (*models.ClientNoShowsResDTO, error) {
+	client, err := s.service.GetClientByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.noShowsDTO(client)
+}
+
+// ClearFlag снимает метку за неявки; неявки до этого момента больше не учитываются правилом.
+func (s *clientService) ClearFlag(id uint) (*models.ClientNoShowsResDTO, error) {
+	if _, err := s.service.GetClientByID(id); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.ClearFlag(id, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.GetNoShows(id)
+}
+
+func (s *clientService) noShowsDTO(client *models.Client) (*models.ClientNoShowsResDTO, error) {
+	recent, err := recentNoShows(s.appointments, s.rule, client, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ClientNoShowsResDTO{
+		ClientID:      client.ID,
+		FullName:      client.FullName,
+		NoShowCount:   client.NoShowCount,
+		RecentNoShows: recent,
+		Flagged:       client.FlaggedAt != nil,
+		FlaggedAt:     client.FlaggedAt,
+		FlagReason:    client.FlagReason,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+)
+
+// publishAppointment сообщает подписчикам об изменении записи. Вызывается после фиксации транзакции,
+// чтобы экраны не увидели запись, которая потом откатилась.
+func publishAppointment(bus *events.Bus, eventType string, appointment *models.Appointments) {
+	bus.Publish(events.Event{
+		Type:          eventType,
+		AppointmentID: appointment.ID,
+		BarberID:      appointment.BarberID,
+		ClientID:      appointment.ClientID,
+		Status:        appointment.Status,
+		StartsAt:      appointment.StartsAt,
+		EndsAt:        appointment.EndsAt,
+	})
+}
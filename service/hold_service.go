@@ -0,0 +1,221 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+const (
+	defaultHoldTTL = 10 * time.Minute
+	maxHoldTTL     = 30 * time.Minute
+)
+
+var errWaitlistHold = errors.New("бронь предложена из очереди ожидания, подтвердите её через очередь")
+
+type HoldService interface {
+	Create(req *models.SlotHoldCreateReqDTO) (*models.SlotHold, error)
+	GetByID(id uint) (*models.SlotHold, error)
+	Confirm(id uint) (*models.Appointments, error)
+	Release(id uint) error
+	ExpireStale() (int, error)
+	RunSweeper(ctx context.Context, interval time.Duration)
+}
+
+type holdService struct {
+	logger       *slog.Logger
+	appointments repository.AppointmentsRepository
+	barbers      repository.BarbersRepository
+	clients      repository.ClientsRepository
+	links        repository.BarberServicesRepository
+	calendar     *WorkCalendar
+	waitlist     WaitlistService
+	events       *events.Bus
+}
+
+func NewHoldService(logger *slog.Logger, appointments repository.AppointmentsRepository, barbers repository.BarbersRepository, clients repository.ClientsRepository, links repository.BarberServicesRepository, calendar *WorkCalendar, waitlist WaitlistService, bus *events.Bus) HoldService {
+	return &holdService{logger: logger, appointments: appointments, barbers: barbers, clients: clients, links: links, calendar: calendar, waitlist: waitlist, events: bus}
+}
+
+// Create держит слот за клиентом на время оформления. Проверки те же, что при записи,
+// а пока бронь действует, слот не выдаётся в поиске и не может быть занят другой записью.
+func (s *holdService) Create(req *models.SlotHoldCreateReqDTO) (*models.SlotHold, error) {
+	ttl := defaultHoldTTL
+	if req.TTLMinutes != nil {
+		ttl = time.Duration(*req.TTLMinutes) * time.Minute
+		if ttl <= 0 || ttl > maxHoldTTL {
+			return nil, errors.New("бронь можно держать от 1 до 30 минут")
+		}
+	}
+
+	client, err := s.clients.GetClientByID(req.ClientID)
+	if err != nil {
+		return nil, errors.New("клиент не найден")
+	}
+	if client.FlaggedAt != nil {
+		return nil, ErrClientFlagged
+	}
+
+	start, err := parseStartsAt(req.StartsAt, s.calendar.loc)
+	if err != nil {
+		return nil, err
+	}
+	barber, slot, booked, err := planSlot(s.barbers, s.links, s.calendar, req.BarberID, start, req.ServiceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := booked.bufferFor(barber)
+	hold := models.SlotHold{
+		BarberID:      barber.ID,
+		ClientID:      req.ClientID,
+		StartsAt:      slot.start,
+		EndsAt:        slot.end,
+		BufferMinutes: int(buffer / time.Minute),
+		Services:      booked.services,
+		Price:         booked.price,
+		Status:        models.HoldActive,
+		Kind:          models.HoldCheckout,
+	}
+	err = s.appointments.Transaction(func(tx repository.AppointmentsRepository) error {
+		if err := tx.LockBarber(barber.ID); err != nil {
+			return err
+		}
+
+		now := s.calendar.now()
+		isFree, err := slotIsFree(tx, barber.ID, slot.start, slot.end.Add(buffer), now, 0, 0)
+		if err != nil {
+			return err
+		}
+		if !isFree {
+			return ErrSlotTaken
+		}
+
+		hold.ExpiresAt = now.Add(ttl)
+		return tx.CreateHold(&hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("слот забронирован",
+		"op", "service.hold.Create",
+		"id", hold.ID,
+		"barber_id", hold.BarberID,
+		"client_id", hold.ClientID,
+		"expires_at", hold.ExpiresAt,
+	)
+	s.localize(&hold)
+	return &hold, nil
+}
+
+func (s *holdService) GetByID(id uint) (*models.SlotHold, error) {
+	hold, err := s.appointments.GetHold(id)
+	if err != nil {
+		return nil, errors.New("бронь не найдена")
+	}
+	s.localize(hold)
+	return hold, nil
+}
+
+// Confirm превращает бронь в запись.
+func (s *holdService) Confirm(id uint) (*models.Appointments, error) {
+	hold, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if hold.Kind == models.HoldWaitlistOffer {
+		return nil, errWaitlistHold
+	}
+
+	appointment, err := confirmHold(s.appointments, s.barbers, s.calendar, s.events, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("бронь подтверждена",
+		"op", "service.hold.Confirm",
+		"id", id,
+		"appointment_id", appointment.ID,
+	)
+	s.calendar.localize(appointment)
+	return appointment, nil
+}
+
+// Release снимает бронь раньше срока, например если клиент закрыл оформление.
+func (s *holdService) Release(id uint) error {
+	hold, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if hold.Kind == models.HoldWaitlistOffer {
+		return errWaitlistHold
+	}
+
+	released, err := s.appointments.UpdateHoldStatus(id, models.HoldActive, models.HoldReleased)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return ErrHoldNotActive
+	}
+
+	s.logger.Info("бронь снята",
+		"op", "service.hold.Release",
+		"id", id,
+	)
+	return nil
+}
+
+// ExpireStale помечает истёкшие брони; истёкшие предложения из очереди передаются следующему клиенту.
+func (s *holdService) ExpireStale() (int, error) {
+	holds, err := s.appointments.ExpireHolds(s.calendar.now())
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range holds {
+		if holds[i].Kind == models.HoldWaitlistOffer {
+			s.waitlist.ExpireOffer(&holds[i])
+		}
+	}
+	return len(holds), nil
+}
+
+// RunSweeper раз в interval снимает истёкшие брони, пока не отменён ctx.
+func (s *holdService) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.ExpireStale()
+			if err != nil {
+				s.logger.Error("ошибка снятия истёкших броней",
+					"op", "service.hold.RunSweeper",
+					"error", err,
+				)
+				continue
+			}
+			if expired > 0 {
+				s.logger.Info("истёкшие брони сняты",
+					"op", "service.hold.RunSweeper",
+					"count", expired,
+				)
+			}
+		}
+	}
+}
+
+func (s *holdService) localize(hold *models.SlotHold) {
+	hold.StartsAt = hold.StartsAt.In(s.calendar.loc)
+	hold.EndsAt = hold.EndsAt.In(s.calendar.loc)
+	hold.ExpiresAt = hold.ExpiresAt.In(s.calendar.loc)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"time"
+)
+
+var (
+	ErrHoldExpired   = errors.New("бронь слота истекла")
+	ErrHoldNotActive = errors.New("бронь уже подтверждена или снята")
+)
+
+// occupiedRanges возвращает время парикмахера, занятое записями и действующими бронями.
+func occupiedRanges(repo repository.AppointmentsRepository, barberID uint, from, to, now time.Time) ([]timeRange, error) {
+	appointments, err := repo.GetByBarberInRange(barberID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	holds, err := repo.GetActiveHoldsInRange(barberID, from, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := busyRanges(appointments)
+	for _, hold := range holds {
+		end := hold.EndsAt.Add(time.Duration(hold.BufferMinutes) * time.Minute)
+		busy = append(busy, timeRange{start: hold.StartsAt, end: end})
+	}
+	return busy, nil
+}
+
+// slotIsFree проверяет внутри транзакции, что [from, to) не пересекается ни с записями, ни с бронями.
+// exceptAppointment и exceptHold исключают из проверки переносимую запись или подтверждаемую бронь.
+func slotIsFree(tx repository.AppointmentsRepository, barberID uint, from, to, now time.Time, exceptAppointment, exceptHold uint) (bool, error) {
+	isBusy, err := tx.HasOverlap(barberID, from, to, exceptAppointment)
+	if err != nil || isBusy {
+		return false, err
+	}
+	isHeld, err := tx.HasActiveHold(barberID, from, to, now, exceptHold)
+	if err != nil {
+		return false, err
+	}
+	return !isHeld, nil
+}
+
+// planSlot выравнивает начало по сетке и проверяет, что парикмахер в это время работает
+// и оказывает выбранные услуги. Занятость слота проверяется позже, в транзакции.
+func planSlot(barbers repository.BarbersRepository, links repository.BarberServicesRepository, calendar *WorkCalendar, barberID uint, start time.Time, serviceIDs []uint) (*models.Barber, timeRange, *bookingServices, error) {
+	start = calendar.alignToGrid(start)
+
+	if start.Before(calendar.now()) {
+		return nil, timeRange{}, nil, errors.New("запись просрочена")
+	}
+
+	barber, err := barbers.GetBarberByID(barberID)
+	if err != nil {
+		return nil, timeRange{}, nil, err
+	}
+
+	booked, err := resolveServices(links, barberID, serviceIDs)
+	if err != nil {
+		return nil, timeRange{}, nil, err
+	}
+
+	slot := timeRange{start: start, end: start.Add(booked.duration)}
+	if err := calendar.checkWorkingTime(barber, slot); err != nil {
+		return nil, timeRange{}, nil, err
+	}
+
+	return barber, slot, booked, nil
+}
+
+// createAppointment сохраняет запись и первую строку её истории статусов; вызывается внутри транзакции.
+func createAppointment(tx repository.AppointmentsRepository, appointment *models.Appointments, now time.Time) error {
+	if appointment.Status == "" {
+		appointment.Status = models.AppointmentBooked
+	}
+	if err := tx.CreateAppointment(appointment); err != nil {
+		return err
+	}
+
+	return tx.AddStatusChange(&models.AppointmentStatusChange{
+		AppointmentID: appointment.ID,
+		To:            appointment.Status,
+		ChangedAt:     now,
+	})
+}
+
+// confirmHold превращает действующую бронь в запись в одной транзакции с блокировкой парикмахера.
+// Пока бронь действовала, могли появиться отгул, праздник или метка клиента, поэтому они проверяются заново.
+func confirmHold(repo repository.AppointmentsRepository, barbers repository.BarbersRepository, calendar *WorkCalendar, bus *events.Bus, holdID uint) (*models.Appointments, error) {
+	var appointment models.Appointments
+	err := repo.Transaction(func(tx repository.AppointmentsRepository) error {
+		hold, err := tx.GetHold(holdID)
+		if err != nil {
+			return errors.New("бронь не найдена")
+		}
+		if err := tx.LockBarber(hold.BarberID); err != nil {
+			return err
+		}
+
+		now := calendar.now()
+		if hold.Status != models.HoldActive {
+			return ErrHoldNotActive
+		}
+		if !hold.ExpiresAt.After(now) {
+			return ErrHoldExpired
+		}
+
+		client, err := tx.Clients().GetClientByID(hold.ClientID)
+		if err != nil {
+			return errors.New("клиент не найден")
+		}
+		if client.FlaggedAt != nil {
+			return ErrClientFlagged
+		}
+		barber, err := barbers.GetBarberByID(hold.BarberID)
+		if err != nil {
+			return err
+		}
+		if err := calendar.checkWorkingTime(barber, timeRange{start: hold.StartsAt, end: hold.EndsAt}); err != nil {
+			return err
+		}
+
+		updated, err := tx.UpdateHoldStatus(hold.ID, models.HoldActive, models.HoldConfirmed)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrHoldNotActive
+		}
+
+		occupiedUntil := hold.EndsAt.Add(time.Duration(hold.BufferMinutes) * time.Minute)
+		isFree, err := slotIsFree(tx, hold.BarberID, hold.StartsAt, occupiedUntil, now, 0, hold.ID)
+		if err != nil {
+			return err
+		}
+		if !isFree {
+			return ErrSlotTaken
+		}
+
+		appointment = models.Appointments{
+			BarberID:      hold.BarberID,
+			ClientID:      hold.ClientID,
+			StartsAt:      hold.StartsAt,
+			EndsAt:        hold.EndsAt,
+			Services:      hold.Services,
+			Price:         hold.Price,
+			BufferMinutes: hold.BufferMinutes,
+		}
+		if err := createAppointment(tx, &appointment, now); err != nil {
+			return err
+		}
+		return tx.SetHoldAppointment(hold.ID, appointment.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishAppointment(bus, events.AppointmentCreated, &appointment)
+	return &appointment, nil
+}
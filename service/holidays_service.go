@@ -0,0 +1,190 @@
+package service
+
+import (
+	"barber-backend-api/internal/ical"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+)
+
+type HolidayService interface {
+	Create(req *models.HolidayCreateReqDTO) (*models.Holiday, error)
+	GetInRange(from, to string) ([]models.Holiday, error)
+	Delete(id uint) error
+	Import(r io.Reader) (int64, error)
+}
+
+type holidayService struct {
+	logger  *slog.Logger
+	loc     *time.Location
+	service repository.HolidaysRepository
+}
+
+func NewHolidayService(logger *slog.Logger, loc *time.Location, service repository.HolidaysRepository) HolidayService {
+	return &holidayService{logger: logger, loc: loc, service: service}
+}
+
+func (s *holidayService) Create(req *models.HolidayCreateReqDTO) (*models.Holiday, error) {
+	date, err := time.Parse(time.DateOnly, req.Date)
+	if err != nil {
+		return nil, errors.New("неправильный формат даты, нужен YYYY-MM-DD")
+	}
+
+	holiday := models.Holiday{
+		Date:       date,
+		Name:       req.Name,
+		ClosedFrom: req.ClosedFrom,
+		ClosedTo:   req.ClosedTo,
+	}
+	if err := validateClosure(&holiday); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.Create(&holiday); err != nil {
+		s.logger.Error("ошибка сохранения праздника",
+			"op", "service.holiday.Create",
+			"date", req.Date,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Info("праздник добавлен",
+		"op", "service.holiday.Create",
+		"id", holiday.ID,
+		"date", req.Date,
+	)
+	return &holiday, nil
+}
+
+func (s *holidayService) GetInRange(from, to string) ([]models.Holiday, error) {
+	now := time.Now().In(s.loc)
+	fromDate := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	toDate := fromDate.AddDate(1, 0, 0)
+
+	if from != "" {
+		t, err := time.Parse(time.DateOnly, from)
+		if err != nil {
+			return nil, errors.New("неправильный формат даты from, нужен YYYY-MM-DD")
+		}
+		fromDate = t
+	}
+	if to != "" {
+		t, err := time.Parse(time.DateOnly, to)
+		if err != nil {
+			return nil, errors.New("неправильный формат даты to, нужен YYYY-MM-DD")
+		}
+		toDate = t.AddDate(0, 0, 1)
+	}
+
+	return s.service.GetInRange(fromDate, toDate)
+}
+
+func (s *holidayService) Delete(id uint) error {
+	if err := s.service.Delete(id); err != nil {
+		s.logger.Error("ошибка удаления праздника",
+			"op", "service.holiday.Delete",
+			"id", id,
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
+
+// Import загружает праздники из iCalendar-файла.
+// Событие на несколько дней раскладывается на отдельные даты,
+// а событие с временем превращается в частичное закрытие салона.
+func (s *holidayService) Import(r io.Reader) (int64, error) {
+	events, err := ical.Parse(r, s.loc)
+	if err != nil {
+		s.logger.Warn("не удалось разобрать iCalendar",
+			"op", "service.holiday.Import",
+			"error", err,
+		)
+		return 0, err
+	}
+
+	var holidays []models.Holiday
+	for _, event := range events {
+		holidays = append(holidays, holidaysFromEvent(event, s.loc)...)
+	}
+
+	imported, err := s.service.CreateBatch(holidays)
+	if err != nil {
+		s.logger.Error("ошибка сохранения импортированных праздников",
+			"op", "service.holiday.Import",
+			"error", err,
+		)
+		return 0, err
+	}
+
+	s.logger.Info("импорт праздников завершён",
+		"op", "service.holiday.Import",
+		"events", len(events),
+		"imported", imported,
+	)
+	return imported, nil
+}
+
+func holidaysFromEvent(event ical.Event, loc *time.Location) []models.Holiday {
+	name := event.Summary
+	if name == "" {
+		name = "Выходной"
+	}
+
+	start := event.Start.In(loc)
+	end := event.End.In(loc)
+	if !end.After(start) {
+		return nil
+	}
+
+	var holidays []models.Holiday
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	for ; ; day = day.AddDate(0, 0, 1) {
+		dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		if !dayStart.Before(end) {
+			break
+		}
+
+		holiday := models.Holiday{Date: day, Name: name}
+		if !event.AllDay {
+			if start.After(dayStart) {
+				from := formatClock(start.Hour()*60 + start.Minute())
+				holiday.ClosedFrom = &from
+			}
+			if end.Before(dayEnd) {
+				to := formatClock(end.Hour()*60 + end.Minute())
+				holiday.ClosedTo = &to
+			}
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays
+}
+
+func validateClosure(holiday *models.Holiday) error {
+	from, to := 0, 24*60
+	if holiday.ClosedFrom != nil {
+		v, err := parseClock(*holiday.ClosedFrom)
+		if err != nil {
+			return err
+		}
+		from = v
+	}
+	if holiday.ClosedTo != nil {
+		v, err := parseClock(*holiday.ClosedTo)
+		if err != nil {
+			return err
+		}
+		to = v
+	}
+	if from >= to {
+		return errors.New("closed_from должно быть раньше closed_to")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrClientFlagged = errors.New("клиент помечен за неявки, запись через администратора")
+
+// initialStatus проверяет клиента перед записью и возвращает статус, с которым создаётся запись.
+func (s *appointmentsService) initialStatus(clientID uint) (string, error) {
+	client, err := s.clients.GetClientByID(clientID)
+	if err != nil {
+		return "", errors.New("клиент не найден")
+	}
+
+	if client.FlaggedAt == nil {
+		return models.AppointmentBooked, nil
+	}
+	if !s.noShows.RequireApproval {
+		return "", ErrClientFlagged
+	}
+	return models.AppointmentPendingApproval, nil
+}
+
+// trackNoShow учитывает неявку клиента и ставит метку, если он превысил лимит правила.
+// Вызывается в транзакции смены статуса, чтобы неявка и метка не разошлись.
+func (s *appointmentsService) trackNoShow(tx repository.AppointmentsRepository, clientID uint) error {
+	clients := tx.Clients()
+	if err := clients.IncrementNoShows(clientID); err != nil {
+		return err
+	}
+
+	client, err := clients.GetClientByID(clientID)
+	if err != nil {
+		return err
+	}
+	if client.FlaggedAt != nil {
+		return nil
+	}
+
+	now := s.calendar.now()
+	recent, err := recentNoShows(tx, s.noShows, client, now)
+	if err != nil {
+		return err
+	}
+	if recent < int64(s.noShows.Limit) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("%d неявки за последние %d дн.", recent, int(s.noShows.Window/(24*time.Hour)))
+	return clients.SetFlag(clientID, now, reason)
+}
+
+// recentNoShows считает неявки клиента за окно правила, но не раньше последнего снятия метки.
+func recentNoShows(appointments repository.AppointmentsRepository, rule models.NoShowRule, client *models.Client, now time.Time) (int64, error) {
+	since := now.Add(-rule.Window)
+	if client.FlagClearedAt != nil && client.FlagClearedAt.After(since) {
+		since = *client.FlagClearedAt
+	}
+	return appointments.CountNoShows(client.ID, since)
+}
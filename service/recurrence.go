@@ -0,0 +1,357 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSeriesOccurrences ограничивает размер серии, чтобы одно правило не заняло расписание на годы вперёд.
+const maxSeriesOccurrences = 52
+
+var ErrNotInSeries = errors.New("запись не входит в серию")
+
+// recurrence — поддерживаемое подмножество RRULE: FREQ=WEEKLY с INTERVAL и COUNT или UNTIL.
+type recurrence struct {
+	rule     string
+	interval int
+	count    int
+	until    time.Time
+}
+
+// parseRecurrence разбирает правило вида "FREQ=WEEKLY;INTERVAL=2;COUNT=10" (префикс "RRULE:" допускается).
+// UNTIL без времени считается включительно до конца дня по часовому поясу салона.
+func parseRecurrence(value string, loc *time.Location) (*recurrence, error) {
+	rule := strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	r := recurrence{rule: rule, interval: 1}
+
+	var freq string
+	for _, part := range strings.Split(rule, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("некорректная часть правила повторения %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, errors.New("INTERVAL должен быть положительным числом")
+			}
+			r.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, errors.New("COUNT должен быть положительным числом")
+			}
+			r.count = n
+		case "UNTIL":
+			until, err := parseUntil(val, loc)
+			if err != nil {
+				return nil, err
+			}
+			r.until = until
+		default:
+			return nil, errors.New("поддерживаются только FREQ, INTERVAL, COUNT и UNTIL")
+		}
+	}
+
+	if freq != "WEEKLY" {
+		return nil, errors.New("поддерживается только FREQ=WEEKLY")
+	}
+	if (r.count == 0) == r.until.IsZero() {
+		return nil, errors.New("укажите ровно одно из COUNT или UNTIL")
+	}
+	if r.count > maxSeriesOccurrences {
+		return nil, fmt.Errorf("в серии может быть не больше %d повторений", maxSeriesOccurrences)
+	}
+
+	return &r, nil
+}
+
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range []string{"20060102", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+		}
+	}
+	return time.Time{}, errors.New("неправильный формат UNTIL, нужен YYYYMMDD или YYYYMMDDTHHMMSSZ")
+}
+
+// occurrences возвращает начала повторений, начиная с first. Шаг считается в календарных днях,
+// поэтому время по настенным часам сохраняется при переходе на летнее время.
+func (r *recurrence) occurrences(first time.Time) ([]time.Time, error) {
+	var starts []time.Time
+	for i := 0; ; i++ {
+		start := first.AddDate(0, 0, 7*r.interval*i)
+		if r.count > 0 && i >= r.count {
+			break
+		}
+		if !r.until.IsZero() && start.After(r.until) {
+			break
+		}
+		if len(starts) == maxSeriesOccurrences {
+			return nil, fmt.Errorf("в серии может быть не больше %d повторений", maxSeriesOccurrences)
+		}
+		starts = append(starts, start)
+	}
+
+	if len(starts) == 0 {
+		return nil, errors.New("правило повторения не даёт ни одной записи")
+	}
+	return starts, nil
+}
+
+// CreateSeries создаёт серию записей по правилу повторения. Каждое повторение проверяется
+// как обычная запись; занятые или нерабочие даты пропускаются и возвращаются в conflicts.
+func (s *appointmentsService) CreateSeries(req *models.AppointmentsCreateDTO) (*models.SeriesResDTO, error) {
+	first, err := parseAppointmentStart(req, s.calendar.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := parseRecurrence(req.Recurrence, s.calendar.loc)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := rule.occurrences(first)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.initialStatus(req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	series := models.AppointmentSeries{
+		ClientID:      req.ClientID,
+		BarberID:      req.BarberID,
+		Rule:          rule.rule,
+		IntervalWeeks: rule.interval,
+	}
+	if rule.count > 0 {
+		series.Count = &rule.count
+	} else {
+		series.Until = &rule.until
+	}
+
+	// Серия и её повторения создаются в одной транзакции: если не записалось ни одно повторение,
+	// откат убирает и саму серию.
+	res := models.SeriesResDTO{Series: &series, Appointments: []models.Appointments{}, Conflicts: []models.SeriesConflictDTO{}}
+	err = s.service.Transaction(func(tx repository.AppointmentsRepository) error {
+		if err := tx.CreateSeries(&series); err != nil {
+			return err
+		}
+
+		for _, start := range starts {
+			appointment, slot, err := s.planOccurrence(req, series.ID, start, status)
+			if err != nil {
+				res.Conflicts = append(res.Conflicts, seriesConflict(0, start, err))
+				continue
+			}
+			err = s.bookIn(tx, appointment, slot)
+			if errors.Is(err, ErrSlotTaken) {
+				res.Conflicts = append(res.Conflicts, seriesConflict(0, start, err))
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			res.Appointments = append(res.Appointments, *appointment)
+		}
+
+		if len(res.Appointments) == 0 {
+			return fmt.Errorf("%w: ни одно повторение серии не удалось записать", ErrSlotTaken)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res.Appointments {
+		publishAppointment(s.events, events.AppointmentCreated, &res.Appointments[i])
+	}
+	res.Appointments = s.calendar.localizeAll(res.Appointments)
+	return &res, nil
+}
+
+// planOccurrence проверяет рабочее время для одного повторения и готовит запись к сохранению.
+func (s *appointmentsService) planOccurrence(req *models.AppointmentsCreateDTO, seriesID uint, start time.Time, status string) (*models.Appointments, timeRange, error) {
+	barber, slot, booked, err := planSlot(s.barber, s.services, s.calendar, req.BarberID, start, req.ServiceIDs)
+	if err != nil {
+		return nil, timeRange{}, err
+	}
+
+	appointment := models.Appointments{
+		BarberID: req.BarberID,
+		ClientID: req.ClientID,
+		StartsAt: slot.start,
+		EndsAt:   slot.end,
+		Services: booked.services,
+		Price:    booked.price,
+
+		BufferMinutes: int(booked.bufferFor(barber) / time.Minute),
+		Status:        status,
+		SeriesID:      &seriesID,
+	}
+	return &appointment, slot, nil
+}
+
+func (s *appointmentsService) GetSeries(id uint) (*models.SeriesResDTO, error) {
+	series, err := s.service.GetSeries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, err := s.service.GetBySeries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SeriesResDTO{
+		Series:       series,
+		Appointments: s.calendar.localizeAll(appointments),
+		Conflicts:    []models.SeriesConflictDTO{},
+	}, nil
+}
+
+// CancelSeries отменяет запись вместе с последующими (scope=following) или всю серию (scope=all).
+// Каждое повторение отменяется по обычным правилам; те, что отменить нельзя, возвращаются в conflicts.
+func (s *appointmentsService) CancelSeries(id uint, scope string) (*models.SeriesResDTO, error) {
+	targets, err := s.seriesTargets(id, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	res := models.SeriesResDTO{Appointments: []models.Appointments{}, Conflicts: []models.SeriesConflictDTO{}}
+	for _, target := range targets {
+		appointment, err := s.Cancel(target.ID)
+		if err != nil {
+			res.Conflicts = append(res.Conflicts, seriesConflict(target.ID, target.StartsAt, err))
+			continue
+		}
+		res.Appointments = append(res.Appointments, *appointment)
+	}
+	return &res, nil
+}
+
+// RescheduleSeries переносит повторения серии. Новое время задаётся для записи id: остальные
+// сдвигаются на столько же дней и получают то же время начала по настенным часам.
+func (s *appointmentsService) RescheduleSeries(id uint, scope string, req *models.AppointmentsRescheduleReqDTO) (*models.SeriesResDTO, error) {
+	start, err := parseStartsAt(req.StartsAt, s.calendar.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	anchor, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := s.seriesTargets(id, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	dayShift := daysBetween(s.calendar.startOfDay(anchor.StartsAt), s.calendar.startOfDay(start))
+	clock := int(start.Sub(s.calendar.startOfDay(start)) / time.Minute)
+
+	// При сдвиге вперёд повторения переносятся с последнего: иначе запись упиралась бы
+	// в ещё не перенесённое следующее повторение, если сдвиг кратен интервалу серии.
+	order := make([]int, len(targets))
+	for i := range targets {
+		order[i] = i
+		if start.After(anchor.StartsAt) {
+			order[i] = len(targets) - 1 - i
+		}
+	}
+
+	res := models.SeriesResDTO{Appointments: []models.Appointments{}, Conflicts: []models.SeriesConflictDTO{}}
+	for _, i := range order {
+		target := &targets[i]
+
+		barberID := target.BarberID
+		if req.BarberID != nil {
+			barberID = *req.BarberID
+		}
+		newStart := atClock(s.calendar.startOfDay(target.StartsAt).AddDate(0, 0, dayShift), clock)
+
+		if err := s.move(target, barberID, newStart); err != nil {
+			res.Conflicts = append(res.Conflicts, seriesConflict(target.ID, target.StartsAt, err))
+			continue
+		}
+
+		appointment, err := s.GetByID(target.ID)
+		if err != nil {
+			return nil, err
+		}
+		res.Appointments = append(res.Appointments, *appointment)
+	}
+
+	sort.Slice(res.Appointments, func(i, j int) bool {
+		return res.Appointments[i].StartsAt.Before(res.Appointments[j].StartsAt)
+	})
+	sort.Slice(res.Conflicts, func(i, j int) bool {
+		return res.Conflicts[i].StartsAt < res.Conflicts[j].StartsAt
+	})
+	return &res, nil
+}
+
+// seriesTargets возвращает ещё не завершённые повторения серии, к которым применяется операция.
+func (s *appointmentsService) seriesTargets(id uint, scope string) ([]models.Appointments, error) {
+	if scope != models.SeriesScopeFollowing && scope != models.SeriesScopeAll {
+		return nil, errors.New("scope должен быть this, following или all")
+	}
+
+	current, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if current.SeriesID == nil {
+		return nil, ErrNotInSeries
+	}
+
+	occurrences, err := s.service.GetBySeries(*current.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []models.Appointments
+	for _, occurrence := range occurrences {
+		if scope == models.SeriesScopeFollowing && occurrence.StartsAt.Before(current.StartsAt) {
+			continue
+		}
+		switch occurrence.Status {
+		case models.AppointmentBooked, models.AppointmentConfirmed, models.AppointmentPendingApproval:
+			targets = append(targets, occurrence)
+		}
+	}
+	return s.calendar.localizeAll(targets), nil
+}
+
+func seriesConflict(appointmentID uint, startsAt time.Time, err error) models.SeriesConflictDTO {
+	return models.SeriesConflictDTO{
+		AppointmentID: appointmentID,
+		StartsAt:      startsAt.Format(time.RFC3339),
+		Reason:        err.Error(),
+	}
+}
+
+// daysBetween считает календарные дни между двумя полуночами, не завися от длины суток при переводе часов.
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func berlin(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("нет базы часовых поясов: %v", err)
+	}
+	return loc
+}
+
+func TestParseRecurrence(t *testing.T) {
+	loc := berlin(t)
+
+	tests := []struct {
+		value    string
+		interval int
+		count    int
+		until    time.Time
+	}{
+		{value: "FREQ=WEEKLY;COUNT=3", interval: 1, count: 3},
+		{value: "RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=10", interval: 2, count: 10},
+		{value: "freq=weekly;interval=3;count=1", interval: 3, count: 1},
+		{value: "FREQ=WEEKLY;UNTIL=20260331", interval: 1, until: time.Date(2026, 3, 31, 23, 59, 59, 999999999, loc)},
+		{value: "FREQ=WEEKLY;UNTIL=2026-03-31", interval: 1, until: time.Date(2026, 3, 31, 23, 59, 59, 999999999, loc)},
+		{value: "FREQ=WEEKLY;UNTIL=20260331T080000Z", interval: 1, until: time.Date(2026, 3, 31, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		r, err := parseRecurrence(tt.value, loc)
+		if err != nil {
+			t.Errorf("%q: неожиданная ошибка: %v", tt.value, err)
+			continue
+		}
+		if r.interval != tt.interval || r.count != tt.count || !r.until.Equal(tt.until) {
+			t.Errorf("%q: получили interval=%d count=%d until=%v, ожидали interval=%d count=%d until=%v",
+				tt.value, r.interval, r.count, r.until, tt.interval, tt.count, tt.until)
+		}
+	}
+}
+
+func TestParseRecurrenceErrors(t *testing.T) {
+	loc := berlin(t)
+
+	for _, value := range []string{
+		"",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=WEEKLY",
+		"FREQ=WEEKLY;COUNT=3;UNTIL=20260331",
+		"FREQ=WEEKLY;COUNT=0",
+		"FREQ=WEEKLY;COUNT=53",
+		"FREQ=WEEKLY;INTERVAL=0;COUNT=3",
+		"FREQ=WEEKLY;INTERVAL=x;COUNT=3",
+		"FREQ=WEEKLY;UNTIL=31.03.2026",
+		"FREQ=WEEKLY;BYDAY=MO;COUNT=3",
+		"FREQ=WEEKLY;COUNT",
+	} {
+		if _, err := parseRecurrence(value, loc); err == nil {
+			t.Errorf("%q: ожидалась ошибка", value)
+		}
+	}
+}
+
+func TestOccurrencesKeepWallClockAcrossDST(t *testing.T) {
+	loc := berlin(t)
+
+	// Переход на летнее время в Берлине — 29 марта 2026.
+	first := time.Date(2026, 3, 23, 10, 0, 0, 0, loc)
+	r, err := parseRecurrence("FREQ=WEEKLY;COUNT=3", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	starts, err := r.occurrences(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{
+		time.Date(2026, 3, 23, 10, 0, 0, 0, loc),
+		time.Date(2026, 3, 30, 10, 0, 0, 0, loc),
+		time.Date(2026, 4, 6, 10, 0, 0, 0, loc),
+	}
+	if len(starts) != len(want) {
+		t.Fatalf("получили %d повторений, ожидали %d", len(starts), len(want))
+	}
+	for i := range want {
+		if !starts[i].Equal(want[i]) {
+			t.Errorf("повторение %d: получили %v, ожидали %v", i, starts[i], want[i])
+		}
+	}
+	if got := starts[1].Sub(starts[0]); got != 7*24*time.Hour-time.Hour {
+		t.Errorf("неделя с переводом часов длится %v, ожидали 167h", got)
+	}
+}
+
+func TestOccurrencesUntilIsInclusive(t *testing.T) {
+	loc := berlin(t)
+	first := time.Date(2026, 3, 3, 18, 0, 0, 0, loc)
+
+	tests := []struct {
+		rule string
+		want int
+	}{
+		// UNTIL-дата совпадает с днём последнего повторения: оно входит в серию.
+		{rule: "FREQ=WEEKLY;UNTIL=20260331", want: 5},
+		{rule: "FREQ=WEEKLY;UNTIL=20260330", want: 4},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;UNTIL=20260331", want: 3},
+		// UNTIL со временем сравнивается точно: 17:00 UTC — это 19:00 по Берлину, позже 18:00.
+		{rule: "FREQ=WEEKLY;UNTIL=20260331T170000Z", want: 5},
+		{rule: "FREQ=WEEKLY;UNTIL=20260331T155959Z", want: 4},
+	}
+	for _, tt := range tests {
+		r, err := parseRecurrence(tt.rule, loc)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.rule, err)
+		}
+		starts, err := r.occurrences(first)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.rule, err)
+		}
+		if len(starts) != tt.want {
+			t.Errorf("%q: получили %d повторений, ожидали %d", tt.rule, len(starts), tt.want)
+		}
+	}
+}
+
+func TestOccurrencesErrors(t *testing.T) {
+	loc := berlin(t)
+	first := time.Date(2026, 3, 3, 18, 0, 0, 0, loc)
+
+	before, err := parseRecurrence("FREQ=WEEKLY;UNTIL=20260302", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := before.occurrences(first); err == nil {
+		t.Error("UNTIL раньше первой записи: ожидалась ошибка")
+	}
+
+	tooLong, err := parseRecurrence("FREQ=WEEKLY;UNTIL=20300101", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tooLong.occurrences(first); err == nil {
+		t.Errorf("UNTIL дальше %d повторений: ожидалась ошибка", maxSeriesOccurrences)
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	loc := berlin(t)
+
+	tests := []struct {
+		from, to time.Time
+		want     int
+	}{
+		{from: time.Date(2026, 3, 3, 0, 0, 0, 0, loc), to: time.Date(2026, 3, 3, 0, 0, 0, 0, loc), want: 0},
+		{from: time.Date(2026, 3, 3, 0, 0, 0, 0, loc), to: time.Date(2026, 3, 10, 0, 0, 0, 0, loc), want: 7},
+		{from: time.Date(2026, 3, 10, 0, 0, 0, 0, loc), to: time.Date(2026, 3, 3, 0, 0, 0, 0, loc), want: -7},
+		// Сутки 29 марта длятся 23 часа, а 25 октября — 25 часов.
+		{from: time.Date(2026, 3, 28, 0, 0, 0, 0, loc), to: time.Date(2026, 3, 30, 0, 0, 0, 0, loc), want: 2},
+		{from: time.Date(2026, 3, 30, 0, 0, 0, 0, loc), to: time.Date(2026, 3, 28, 0, 0, 0, 0, loc), want: -2},
+		{from: time.Date(2026, 10, 24, 0, 0, 0, 0, loc), to: time.Date(2026, 10, 26, 0, 0, 0, 0, loc), want: 2},
+		{from: time.Date(2026, 12, 31, 0, 0, 0, 0, loc), to: time.Date(2027, 1, 1, 0, 0, 0, 0, loc), want: 1},
+	}
+	for _, tt := range tests {
+		if got := daysBetween(tt.from, tt.to); got != tt.want {
+			t.Errorf("daysBetween(%v, %v) = %d, ожидали %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
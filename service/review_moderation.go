@@ -0,0 +1,33 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"strings"
+	"unicode"
+)
+
+// screenReview возвращает слова фильтра, найденные в тексте. Бранные основы сравниваются с началом
+// каждого слова, ключевые слова салона ищутся как подстрока. Буква «ё» приравнивается к «е».
+func screenReview(filter models.ReviewFilter, text string) []string {
+	normalized := strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	words := strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var found []string
+	for _, stem := range filter.BlockedWords {
+		stem = strings.ReplaceAll(stem, "ё", "е")
+		for _, word := range words {
+			if strings.HasPrefix(word, stem) {
+				found = append(found, stem)
+				break
+			}
+		}
+	}
+	for _, keyword := range filter.Keywords {
+		if strings.Contains(normalized, strings.ReplaceAll(keyword, "ё", "е")) {
+			found = append(found, keyword)
+		}
+	}
+	return found
+}
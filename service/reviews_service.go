@@ -0,0 +1,248 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotAppointmentOwner = errors.New("отзыв может оставить только клиент этой записи")
+	ErrReviewExists        = errors.New("на эту запись уже оставлен отзыв")
+	ErrReplyExists         = errors.New("парикмахер уже ответил на этот отзыв")
+)
+
+type ReviewsService interface {
+	Create(appointmentID uint, req *models.ReviewCreateReqDTO) (*models.Review, error)
+	GetByAppointmentID(appointmentID uint) (*models.Review, error)
+	GetByBarberID(barberID uint) ([]models.Review, error)
+	GetRating(barberID uint) (*models.BarberRatingResDTO, error)
+	GetForModeration(status string) ([]models.Review, error)
+	Moderate(id uint, req *models.ReviewModerateReqDTO) (*models.Review, error)
+	Reply(id uint, req *models.ReviewReplyReqDTO) (*models.Review, error)
+}
+
+type reviewsService struct {
+	logger       *slog.Logger
+	service      repository.ReviewsRepository
+	appointments repository.AppointmentsRepository
+	barbers      repository.BarbersRepository
+	filter       models.ReviewFilter
+	prior        models.RatingPrior
+	events       *events.Bus
+}
+
+func NewReviewsService(logger *slog.Logger, service repository.ReviewsRepository, appointments repository.AppointmentsRepository, barbers repository.BarbersRepository, filter models.ReviewFilter, prior models.RatingPrior, bus *events.Bus) ReviewsService {
+	return &reviewsService{logger: logger, service: service, appointments: appointments, barbers: barbers, filter: filter, prior: prior, events: bus}
+}
+
+// Create сохраняет отзыв о конкретной записи. Оставить его может только клиент этой записи
+// и только после завершённого визита. Отзыв публикуется сразу, если текст прошёл фильтр,
+// иначе ждёт модерации и до тех пор не влияет на рейтинг парикмахера.
+func (s *reviewsService) Create(appointmentID uint, req *models.ReviewCreateReqDTO) (*models.Review, error) {
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, errors.New("оценка должна быть от 1 до 5")
+	}
+
+	appointment, err := s.appointments.GetByID(appointmentID)
+	if err != nil {
+		return nil, errors.New("запись не найдена")
+	}
+	if appointment.ClientID != req.ClientID {
+		return nil, ErrNotAppointmentOwner
+	}
+	if appointment.Status != models.AppointmentCompleted {
+		return nil, errors.New("оценку можно ставить после услуги")
+	}
+
+	review := models.Review{
+		AppointmentID: appointment.ID,
+		ClientID:      appointment.ClientID,
+		BarberID:      appointment.BarberID,
+		Rating:        req.Rating,
+		Text:          strings.TrimSpace(req.Text),
+		Status:        models.ReviewPublished,
+	}
+	if found := screenReview(s.filter, review.Text); len(found) > 0 {
+		review.Status = models.ReviewPending
+		review.ModerationReason = "фильтр: " + strings.Join(found, ", ")
+	}
+	created, err := s.service.Create(&review)
+	if err != nil {
+		s.logger.Error("ошибка сохранения отзыва",
+			"op", "service.reviews.Create",
+			"appointment_id", appointmentID,
+			"error", err,
+		)
+		return nil, err
+	}
+	if !created {
+		return nil, ErrReviewExists
+	}
+
+	s.logger.Info("отзыв сохранён",
+		"op", "service.reviews.Create",
+		"id", review.ID,
+		"appointment_id", review.AppointmentID,
+		"barber_id", review.BarberID,
+		"rating", review.Rating,
+		"status", review.Status,
+	)
+	publishAppointment(s.events, events.AppointmentRated, appointment)
+	return &review, nil
+}
+
+func (s *reviewsService) GetByAppointmentID(appointmentID uint) (*models.Review, error) {
+	review, err := s.service.GetByAppointmentID(appointmentID)
+	if err != nil || review.Status != models.ReviewPublished {
+		return nil, errors.New("отзыв не найден")
+	}
+	return review, nil
+}
+
+func (s *reviewsService) GetByBarberID(barberID uint) ([]models.Review, error) {
+	isExist, err := s.barbers.Exists(barberID)
+	if err != nil {
+		return nil, err
+	}
+	if !isExist {
+		return nil, errors.New("парикмахер не найден")
+	}
+	return s.service.GetPublishedByBarberID(barberID, 0)
+}
+
+// GetRating возвращает рейтинг парикмахера по опубликованным отзывам: простое и байесовское среднее
+// и распределение оценок. Байесовское среднее тянет рейтинг с малым числом отзывов к средней по салону.
+func (s *reviewsService) GetRating(barberID uint) (*models.BarberRatingResDTO, error) {
+	isExist, err := s.barbers.Exists(barberID)
+	if err != nil {
+		return nil, err
+	}
+	if !isExist {
+		return nil, errors.New("парикмахер не найден")
+	}
+
+	stats, err := s.service.GetRatingStats(barberID)
+	if err != nil {
+		return nil, err
+	}
+	globalSum, globalCount, err := s.service.GetGlobalRating()
+	if err != nil {
+		return nil, err
+	}
+
+	return ratingSummary(stats, globalSum, globalCount, s.prior), nil
+}
+
+func ratingSummary(stats *models.BarberRatingStats, globalSum, globalCount int64, prior models.RatingPrior) *models.BarberRatingResDTO {
+	res := &models.BarberRatingResDTO{
+		BarberID: stats.BarberID,
+		Count:    stats.RatingCount,
+		Histogram: map[int]int64{
+			1: stats.Stars1,
+			2: stats.Stars2,
+			3: stats.Stars3,
+			4: stats.Stars4,
+			5: stats.Stars5,
+		},
+	}
+	if stats.RatingCount > 0 {
+		res.Average = float64(stats.RatingSum) / float64(stats.RatingCount)
+	}
+	if globalCount > 0 {
+		globalMean := float64(globalSum) / float64(globalCount)
+		res.WeightedAverage = (prior.Weight*globalMean + float64(stats.RatingSum)) / (prior.Weight + float64(stats.RatingCount))
+	}
+	return res
+}
+
+// GetForModeration возвращает отзывы в статусе status (по умолчанию — ожидающие модерации).
+func (s *reviewsService) GetForModeration(status string) ([]models.Review, error) {
+	switch status {
+	case "":
+		status = models.ReviewPending
+	case "all":
+		status = ""
+	case models.ReviewPending, models.ReviewPublished, models.ReviewHidden:
+	default:
+		return nil, errors.New("статус должен быть pending, published, hidden или all")
+	}
+	return s.service.GetByStatus(status)
+}
+
+// Moderate публикует, скрывает или возвращает отзыв на модерацию; рейтинг парикмахера пересчитывается.
+func (s *reviewsService) Moderate(id uint, req *models.ReviewModerateReqDTO) (*models.Review, error) {
+	switch req.Status {
+	case models.ReviewPending, models.ReviewPublished, models.ReviewHidden:
+	default:
+		return nil, errors.New("статус должен быть pending, published или hidden")
+	}
+
+	review, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, errors.New("отзыв не найден")
+	}
+	if review.Status == req.Status {
+		return review, nil
+	}
+
+	updated, err := s.service.UpdateStatus(id, review.Status, req.Status, strings.TrimSpace(req.Reason), time.Now())
+	if err != nil {
+		s.logger.Error("ошибка модерации отзыва",
+			"op", "service.reviews.Moderate",
+			"id", id,
+			"error", err,
+		)
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrStatusChanged
+	}
+
+	s.logger.Info("отзыв промодерирован",
+		"op", "service.reviews.Moderate",
+		"id", id,
+		"from", review.Status,
+		"to", req.Status,
+	)
+	return s.service.GetByID(id)
+}
+
+// Reply сохраняет публичный ответ парикмахера на отзыв о нём. Ответ один и проходит тот же фильтр,
+// но, в отличие от отзыва, с бранью не сохраняется вовсе.
+func (s *reviewsService) Reply(id uint, req *models.ReviewReplyReqDTO) (*models.Review, error) {
+	review, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, errors.New("отзыв не найден")
+	}
+	if review.BarberID != req.BarberID {
+		return nil, errors.New("ответить может только парикмахер, о котором отзыв")
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, errors.New("ответ не может быть пустым")
+	}
+	if found := screenReview(s.filter, text); len(found) > 0 {
+		return nil, errors.New("ответ содержит недопустимые слова: " + strings.Join(found, ", "))
+	}
+
+	saved, err := s.service.SetReply(id, text, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrReplyExists
+	}
+
+	s.logger.Info("парикмахер ответил на отзыв",
+		"op", "service.reviews.Reply",
+		"id", id,
+		"barber_id", req.BarberID,
+	)
+	return s.service.GetByID(id)
+}
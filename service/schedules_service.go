@@ -0,0 +1,284 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+type BarberScheduleService interface {
+	GetByBarberID(barberID uint) ([]models.BarberScheduleResDTO, error)
+	Create(barberID uint, req *models.BarberScheduleReqDTO) (*models.BarberScheduleResDTO, error)
+	Update(barberID, scheduleID uint, req *models.BarberScheduleReqDTO) (*models.BarberScheduleResDTO, error)
+	Delete(barberID, scheduleID uint) error
+}
+
+type barberScheduleService struct {
+	logger  *slog.Logger
+	service repository.SchedulesRepository
+	barbers repository.BarbersRepository
+}
+
+func NewBarberScheduleService(logger *slog.Logger, service repository.SchedulesRepository, barbers repository.BarbersRepository) BarberScheduleService {
+	return &barberScheduleService{logger: logger, service: service, barbers: barbers}
+}
+
+func (s *barberScheduleService) GetByBarberID(barberID uint) ([]models.BarberScheduleResDTO, error) {
+	if err := s.ensureBarber(barberID, "service.schedule.GetByBarberID"); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.service.GetByBarberID(barberID)
+	if err != nil {
+		s.logger.Error("ошибка получения графиков парикмахера",
+			"op", "service.schedule.GetByBarberID",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	res := make([]models.BarberScheduleResDTO, 0, len(schedules))
+	for _, schedule := range schedules {
+		res = append(res, scheduleToDTO(schedule))
+	}
+	return res, nil
+}
+
+func (s *barberScheduleService) Create(barberID uint, req *models.BarberScheduleReqDTO) (*models.BarberScheduleResDTO, error) {
+	if err := s.ensureBarber(barberID, "service.schedule.Create"); err != nil {
+		return nil, err
+	}
+
+	schedule, err := scheduleFromDTO(req)
+	if err != nil {
+		s.logger.Warn("некорректный график",
+			"op", "service.schedule.Create",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return nil, err
+	}
+	schedule.BarberID = barberID
+
+	if err := s.service.Create(schedule); err != nil {
+		s.logger.Error("ошибка сохранения графика",
+			"op", "service.schedule.Create",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Info("график парикмахера создан",
+		"op", "service.schedule.Create",
+		"barber_id", barberID,
+		"id", schedule.ID,
+	)
+	res := scheduleToDTO(*schedule)
+	return &res, nil
+}
+
+func (s *barberScheduleService) Update(barberID, scheduleID uint, req *models.BarberScheduleReqDTO) (*models.BarberScheduleResDTO, error) {
+	if _, err := s.getOwned(barberID, scheduleID, "service.schedule.Update"); err != nil {
+		return nil, err
+	}
+
+	schedule, err := scheduleFromDTO(req)
+	if err != nil {
+		s.logger.Warn("некорректный график",
+			"op", "service.schedule.Update",
+			"id", scheduleID,
+			"error", err,
+		)
+		return nil, err
+	}
+	schedule.ID = scheduleID
+	schedule.BarberID = barberID
+
+	if err := s.service.Update(schedule); err != nil {
+		s.logger.Error("ошибка обновления графика",
+			"op", "service.schedule.Update",
+			"id", scheduleID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	updated, err := s.service.GetByID(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("график парикмахера обновлён",
+		"op", "service.schedule.Update",
+		"barber_id", barberID,
+		"id", scheduleID,
+	)
+	res := scheduleToDTO(*updated)
+	return &res, nil
+}
+
+func (s *barberScheduleService) Delete(barberID, scheduleID uint) error {
+	if _, err := s.getOwned(barberID, scheduleID, "service.schedule.Delete"); err != nil {
+		return err
+	}
+
+	if err := s.service.Delete(scheduleID); err != nil {
+		s.logger.Error("ошибка удаления графика",
+			"op", "service.schedule.Delete",
+			"id", scheduleID,
+			"error", err,
+		)
+		return err
+	}
+
+	s.logger.Info("график парикмахера удалён",
+		"op", "service.schedule.Delete",
+		"barber_id", barberID,
+		"id", scheduleID,
+	)
+	return nil
+}
+
+func (s *barberScheduleService) ensureBarber(barberID uint, op string) error {
+	isExist, err := s.barbers.Exists(barberID)
+	if err != nil {
+		s.logger.Error("ошибка проверки существования парикмахера",
+			"op", op,
+			"barber_id", barberID,
+			"error", err,
+		)
+		return err
+	}
+	if !isExist {
+		s.logger.Warn("парикмахер не найден",
+			"op", op,
+			"barber_id", barberID,
+		)
+		return errors.New("парикмахер не найден")
+	}
+	return nil
+}
+
+func (s *barberScheduleService) getOwned(barberID, scheduleID uint, op string) (*models.BarberSchedule, error) {
+	schedule, err := s.service.GetByID(scheduleID)
+	if err != nil {
+		s.logger.Warn("график не найден",
+			"op", op,
+			"id", scheduleID,
+			"error", err,
+		)
+		return nil, err
+	}
+	if schedule.BarberID != barberID {
+		s.logger.Warn("график принадлежит другому парикмахеру",
+			"op", op,
+			"id", scheduleID,
+			"barber_id", barberID,
+		)
+		return nil, errors.New("график не найден")
+	}
+	return schedule, nil
+}
+
+func scheduleFromDTO(req *models.BarberScheduleReqDTO) (*models.BarberSchedule, error) {
+	from, err := time.Parse(time.DateOnly, req.EffectiveFrom)
+	if err != nil {
+		return nil, errors.New("неправильный формат даты effective_from, нужен YYYY-MM-DD")
+	}
+
+	schedule := models.BarberSchedule{EffectiveFrom: from}
+
+	if req.EffectiveTo != nil {
+		to, err := time.Parse(time.DateOnly, *req.EffectiveTo)
+		if err != nil {
+			return nil, errors.New("неправильный формат даты effective_to, нужен YYYY-MM-DD")
+		}
+		if to.Before(from) {
+			return nil, errors.New("effective_to не может быть раньше effective_from")
+		}
+		schedule.EffectiveTo = &to
+	}
+
+	for name, intervals := range req.Days {
+		weekday, ok := weekdayNames[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("неизвестный день недели %q", name)
+		}
+
+		type clockRange struct{ start, end int }
+		parsed := make([]clockRange, 0, len(intervals))
+		for _, interval := range intervals {
+			start, err := parseClock(interval.Start)
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseClock(interval.End)
+			if err != nil {
+				return nil, err
+			}
+			if start >= end {
+				return nil, fmt.Errorf("интервал %s-%s: начало должно быть раньше окончания", interval.Start, interval.End)
+			}
+			parsed = append(parsed, clockRange{start: start, end: end})
+		}
+
+		sort.Slice(parsed, func(i, j int) bool { return parsed[i].start < parsed[j].start })
+		for i := 1; i < len(parsed); i++ {
+			if parsed[i].start < parsed[i-1].end {
+				return nil, fmt.Errorf("интервалы дня %q пересекаются", name)
+			}
+		}
+
+		for _, p := range parsed {
+			schedule.Intervals = append(schedule.Intervals, models.ScheduleInterval{
+				Weekday: int(weekday),
+				Start:   formatClock(p.start),
+				End:     formatClock(p.end),
+			})
+		}
+	}
+
+	return &schedule, nil
+}
+
+func scheduleToDTO(schedule models.BarberSchedule) models.BarberScheduleResDTO {
+	res := models.BarberScheduleResDTO{
+		ID:            schedule.ID,
+		BarberID:      schedule.BarberID,
+		EffectiveFrom: schedule.EffectiveFrom.Format(time.DateOnly),
+		Days:          make(map[string][]models.ScheduleIntervalDTO),
+	}
+	if schedule.EffectiveTo != nil {
+		to := schedule.EffectiveTo.Format(time.DateOnly)
+		res.EffectiveTo = &to
+	}
+
+	for name, weekday := range weekdayNames {
+		for _, interval := range schedule.Intervals {
+			if time.Weekday(interval.Weekday) == weekday {
+				res.Days[name] = append(res.Days[name], models.ScheduleIntervalDTO{
+					Start: interval.Start,
+					End:   interval.End,
+				})
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var timeOffKinds = map[string]bool{
+	models.TimeOffVacation: true,
+	models.TimeOffSick:     true,
+	models.TimeOffOther:    true,
+}
+
+type TimeOffService interface {
+	Create(barberID uint, req *models.TimeOffCreateReqDTO) (*models.TimeOffCreateResDTO, error)
+	GetByBarberID(barberID uint) ([]models.TimeOff, error)
+	Cancel(barberID, timeOffID uint) (*models.TimeOff, error)
+}
+
+type timeOffService struct {
+	logger       *slog.Logger
+	loc          *time.Location
+	service      repository.TimeOffRepository
+	barbers      repository.BarbersRepository
+	appointments repository.AppointmentsRepository
+}
+
+func NewTimeOffService(logger *slog.Logger, loc *time.Location, service repository.TimeOffRepository, barbers repository.BarbersRepository, appointments repository.AppointmentsRepository) TimeOffService {
+	return &timeOffService{logger: logger, loc: loc, service: service, barbers: barbers, appointments: appointments}
+}
+
+func (s *timeOffService) Create(barberID uint, req *models.TimeOffCreateReqDTO) (*models.TimeOffCreateResDTO, error) {
+	if err := s.ensureBarber(barberID, "service.time_off.Create"); err != nil {
+		return nil, err
+	}
+
+	kind := req.Kind
+	if kind == "" {
+		kind = models.TimeOffOther
+	}
+	if !timeOffKinds[kind] {
+		return nil, errors.New("тип отсутствия должен быть vacation, sick или other")
+	}
+
+	start, err := parseTimeOffBound(req.Start, false, s.loc)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeOffBound(req.End, true, s.loc)
+	if err != nil {
+		return nil, err
+	}
+	if !start.Before(end) {
+		return nil, errors.New("начало отсутствия должно быть раньше окончания")
+	}
+
+	timeOff := models.TimeOff{
+		BarberID: barberID,
+		Kind:     kind,
+		Start:    start,
+		End:      end,
+		Note:     req.Note,
+	}
+
+	if err := s.service.Create(&timeOff); err != nil {
+		s.logger.Error("ошибка сохранения отсутствия",
+			"op", "service.time_off.Create",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	conflicts, err := s.conflicts(barberID, timeRange{start: start, end: end})
+	if err != nil {
+		s.logger.Error("ошибка поиска пересекающихся записей",
+			"op", "service.time_off.Create",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Info("отсутствие парикмахера добавлено",
+		"op", "service.time_off.Create",
+		"barber_id", barberID,
+		"id", timeOff.ID,
+		"conflicts", len(conflicts),
+	)
+	return &models.TimeOffCreateResDTO{TimeOff: timeOff, Conflicts: conflicts}, nil
+}
+
+func (s *timeOffService) GetByBarberID(barberID uint) ([]models.TimeOff, error) {
+	if err := s.ensureBarber(barberID, "service.time_off.GetByBarberID"); err != nil {
+		return nil, err
+	}
+
+	timeOffs, err := s.service.GetByBarberID(barberID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range timeOffs {
+		s.localize(&timeOffs[i])
+	}
+	return timeOffs, nil
+}
+
+func (s *timeOffService) Cancel(barberID, timeOffID uint) (*models.TimeOff, error) {
+	timeOff, err := s.service.GetByID(timeOffID)
+	if err != nil {
+		return nil, err
+	}
+	if timeOff.BarberID != barberID {
+		return nil, errors.New("отсутствие не найдено")
+	}
+	if timeOff.CancelledAt != nil {
+		return nil, errors.New("отсутствие уже отменено")
+	}
+
+	if err := s.service.Cancel(timeOffID, time.Now()); err != nil {
+		s.logger.Error("ошибка отмены отсутствия",
+			"op", "service.time_off.Cancel",
+			"id", timeOffID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Info("отсутствие парикмахера отменено",
+		"op", "service.time_off.Cancel",
+		"barber_id", barberID,
+		"id", timeOffID,
+	)
+	cancelled, err := s.service.GetByID(timeOffID)
+	if err != nil {
+		return nil, err
+	}
+	s.localize(cancelled)
+	return cancelled, nil
+}
+
+func (s *timeOffService) localize(timeOff *models.TimeOff) {
+	timeOff.Start = timeOff.Start.In(s.loc)
+	timeOff.End = timeOff.End.In(s.loc)
+}
+
+func (s *timeOffService) conflicts(barberID uint, period timeRange) ([]models.Appointments, error) {
+	conflicts, err := s.appointments.GetByBarberInRange(barberID, period.start, period.end)
+	if err != nil {
+		return nil, err
+	}
+	if conflicts == nil {
+		conflicts = []models.Appointments{}
+	}
+	for i := range conflicts {
+		conflicts[i].StartsAt = conflicts[i].StartsAt.In(s.loc)
+		conflicts[i].EndsAt = conflicts[i].EndsAt.In(s.loc)
+	}
+	return conflicts, nil
+}
+
+func (s *timeOffService) ensureBarber(barberID uint, op string) error {
+	isExist, err := s.barbers.Exists(barberID)
+	if err != nil {
+		s.logger.Error("ошибка проверки существования парикмахера",
+			"op", op,
+			"barber_id", barberID,
+			"error", err,
+		)
+		return err
+	}
+	if !isExist {
+		return errors.New("парикмахер не найден")
+	}
+	return nil
+}
+
+// parseTimeOffBound принимает дату "YYYY-MM-DD" или дату со временем "YYYY-MM-DD HH:MM".
+// Дата без времени на конце периода означает, что день отсутствия включается целиком.
+func parseTimeOffBound(value string, isEnd bool, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, loc); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, loc)
+	if err != nil {
+		return time.Time{}, errors.New("неправильный формат даты, нужен YYYY-MM-DD или YYYY-MM-DD HH:MM")
+	}
+	if isEnd {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// waitlistOfferTTL — сколько клиент из очереди может подтверждать предложенный слот.
+const waitlistOfferTTL = 15 * time.Minute
+
+type WaitlistService interface {
+	Join(req *models.WaitlistJoinReqDTO) (*models.WaitlistEntry, error)
+	GetAll(barberID uint, date string) ([]models.WaitlistEntry, error)
+	GetByID(id uint) (*models.WaitlistEntry, error)
+	Claim(id uint) (*models.Appointments, error)
+	Leave(id uint) error
+	OfferFreedSlot(barberID uint, start, end time.Time)
+	ExpireOffer(hold *models.SlotHold)
+}
+
+type waitlistService struct {
+	logger       *slog.Logger
+	service      repository.WaitlistRepository
+	appointments repository.AppointmentsRepository
+	barbers      repository.BarbersRepository
+	clients      repository.ClientsRepository
+	links        repository.BarberServicesRepository
+	calendar     *WorkCalendar
+	events       *events.Bus
+}
+
+func NewWaitlistService(logger *slog.Logger, service repository.WaitlistRepository, appointments repository.AppointmentsRepository, barbers repository.BarbersRepository, clients repository.ClientsRepository, links repository.BarberServicesRepository, calendar *WorkCalendar, bus *events.Bus) WaitlistService {
+	return &waitlistService{logger: logger, service: service, appointments: appointments, barbers: barbers, clients: clients, links: links, calendar: calendar, events: bus}
+}
+
+func (s *waitlistService) Join(req *models.WaitlistJoinReqDTO) (*models.WaitlistEntry, error) {
+	client, err := s.clients.GetClientByID(req.ClientID)
+	if err != nil {
+		return nil, errors.New("клиент не найден")
+	}
+	if client.FlaggedAt != nil {
+		return nil, ErrClientFlagged
+	}
+
+	isExist, err := s.barbers.Exists(req.BarberID)
+	if err != nil {
+		return nil, err
+	}
+	if !isExist {
+		return nil, errors.New("парикмахер не найден")
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, req.Date, s.calendar.loc)
+	if err != nil {
+		return nil, errors.New("неправильный формат даты, нужен YYYY-MM-DD")
+	}
+	if date.Before(s.calendar.startOfDay(s.calendar.now())) {
+		return nil, errors.New("нельзя встать в очередь на прошедший день")
+	}
+
+	if (req.WindowStart == nil) != (req.WindowEnd == nil) {
+		return nil, errors.New("нужно указать и начало, и конец удобного времени")
+	}
+	if req.WindowStart != nil {
+		from, err := parseClock(*req.WindowStart)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseClock(*req.WindowEnd)
+		if err != nil {
+			return nil, err
+		}
+		if from >= to {
+			return nil, errors.New("начало удобного времени должно быть раньше конца")
+		}
+	}
+
+	booked, err := resolveServices(s.links, req.BarberID, req.ServiceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := models.WaitlistEntry{
+		ClientID:    req.ClientID,
+		BarberID:    req.BarberID,
+		Date:        date,
+		WindowStart: req.WindowStart,
+		WindowEnd:   req.WindowEnd,
+		Services:    booked.services,
+		Status:      models.WaitlistWaiting,
+	}
+	if err := s.service.Create(&entry); err != nil {
+		s.logger.Error("ошибка добавления в очередь ожидания",
+			"op", "service.waitlist.Join",
+			"client_id", req.ClientID,
+			"barber_id", req.BarberID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	s.logger.Info("клиент добавлен в очередь ожидания",
+		"op", "service.waitlist.Join",
+		"id", entry.ID,
+		"client_id", entry.ClientID,
+		"barber_id", entry.BarberID,
+		"date", req.Date,
+	)
+	return &entry, nil
+}
+
+func (s *waitlistService) GetAll(barberID uint, date string) ([]models.WaitlistEntry, error) {
+	var day *time.Time
+	if date != "" {
+		parsed, err := time.ParseInLocation(time.DateOnly, date, s.calendar.loc)
+		if err != nil {
+			return nil, errors.New("неправильный формат даты, нужен YYYY-MM-DD")
+		}
+		day = &parsed
+	}
+	return s.service.GetAll(barberID, day)
+}
+
+func (s *waitlistService) GetByID(id uint) (*models.WaitlistEntry, error) {
+	entry, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, errors.New("запись в очереди не найдена")
+	}
+	return entry, nil
+}
+
+// Claim подтверждает предложенный клиенту слот и создаёт запись.
+func (s *waitlistService) Claim(id uint) (*models.Appointments, error) {
+	entry, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != models.WaitlistOffered || entry.HoldID == nil {
+		return nil, errors.New("клиенту пока не предложено свободное время")
+	}
+
+	appointment, err := confirmHold(s.appointments, s.barbers, s.calendar, s.events, *entry.HoldID)
+	if errors.Is(err, ErrHoldExpired) {
+		if _, updateErr := s.service.UpdateStatus(entry.ID, models.WaitlistOffered, map[string]any{"status": models.WaitlistExpired}); updateErr != nil {
+			s.logger.Error("ошибка пометки истёкшего предложения",
+				"op", "service.waitlist.Claim",
+				"id", entry.ID,
+				"error", updateErr,
+			)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.service.UpdateStatus(entry.ID, models.WaitlistOffered, map[string]any{
+		"status":         models.WaitlistBooked,
+		"appointment_id": appointment.ID,
+	}); err != nil {
+		s.logger.Error("ошибка обновления очереди после записи",
+			"op", "service.waitlist.Claim",
+			"id", entry.ID,
+			"appointment_id", appointment.ID,
+			"error", err,
+		)
+	}
+
+	s.logger.Info("клиент из очереди записан",
+		"op", "service.waitlist.Claim",
+		"id", entry.ID,
+		"appointment_id", appointment.ID,
+	)
+	s.calendar.localize(appointment)
+	return appointment, nil
+}
+
+// Leave убирает клиента из очереди. Если ему уже предложили слот, бронь снимается
+// и слот предлагается следующему в очереди.
+func (s *waitlistService) Leave(id uint) error {
+	entry, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if entry.Status != models.WaitlistWaiting && entry.Status != models.WaitlistOffered {
+		return errors.New("клиент уже не стоит в очереди")
+	}
+
+	updated, err := s.service.UpdateStatus(entry.ID, entry.Status, map[string]any{"status": models.WaitlistCancelled})
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrStatusChanged
+	}
+
+	if entry.Status == models.WaitlistOffered && entry.Hold != nil {
+		released, err := s.appointments.UpdateHoldStatus(entry.Hold.ID, models.HoldActive, models.HoldReleased)
+		if err != nil {
+			return err
+		}
+		if released {
+			s.OfferFreedSlot(entry.Hold.BarberID, entry.Hold.StartsAt, entry.Hold.EndsAt)
+		}
+	}
+
+	s.logger.Info("клиент покинул очередь ожидания",
+		"op", "service.waitlist.Leave",
+		"id", entry.ID,
+	)
+	return nil
+}
+
+// OfferFreedSlot предлагает освободившееся время первому подходящему клиенту из очереди:
+// на слот ставится бронь на waitlistOfferTTL, которую клиент может подтвердить через Claim.
+// Ошибки только логируются, потому что освобождение слота уже состоялось.
+func (s *waitlistService) OfferFreedSlot(barberID uint, start, end time.Time) {
+	now := s.calendar.now()
+	if !start.After(now) {
+		return
+	}
+
+	entries, err := s.service.GetWaiting(barberID, s.calendar.startOfDay(start))
+	if err != nil {
+		s.logger.Error("ошибка получения очереди ожидания",
+			"op", "service.waitlist.OfferFreedSlot",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	barber, err := s.barbers.GetBarberByID(barberID)
+	if err != nil {
+		s.logger.Error("ошибка получения парикмахера",
+			"op", "service.waitlist.OfferFreedSlot",
+			"barber_id", barberID,
+			"error", err,
+		)
+		return
+	}
+
+	for i := range entries {
+		hold, err := s.offer(barber, &entries[i], start)
+		if err != nil {
+			s.logger.Info("слот не подошёл клиенту из очереди",
+				"op", "service.waitlist.OfferFreedSlot",
+				"id", entries[i].ID,
+				"reason", err,
+			)
+			continue
+		}
+
+		s.logger.Info("освободившийся слот предложен клиенту из очереди",
+			"op", "service.waitlist.OfferFreedSlot",
+			"id", entries[i].ID,
+			"hold_id", hold.ID,
+			"starts_at", hold.StartsAt,
+			"expires_at", hold.ExpiresAt,
+		)
+		return
+	}
+}
+
+// ExpireOffer вызывается, когда истекла бронь, предложенная клиенту из очереди:
+// предложение помечается истёкшим, а слот предлагается следующему.
+func (s *waitlistService) ExpireOffer(hold *models.SlotHold) {
+	entry, err := s.service.GetByHoldID(hold.ID)
+	if err == nil {
+		if _, err = s.service.UpdateStatus(entry.ID, models.WaitlistOffered, map[string]any{"status": models.WaitlistExpired}); err == nil {
+			s.logger.Info("предложение из очереди истекло",
+				"op", "service.waitlist.ExpireOffer",
+				"id", entry.ID,
+				"hold_id", hold.ID,
+			)
+		}
+	}
+	if err != nil {
+		s.logger.Error("ошибка пометки истёкшего предложения",
+			"op", "service.waitlist.ExpireOffer",
+			"hold_id", hold.ID,
+			"error", err,
+		)
+	}
+
+	s.OfferFreedSlot(hold.BarberID, hold.StartsAt, hold.EndsAt)
+}
+
+func (s *waitlistService) offer(barber *models.Barber, entry *models.WaitlistEntry, start time.Time) (*models.SlotHold, error) {
+	serviceIDs := make([]uint, 0, len(entry.Services))
+	for _, svc := range entry.Services {
+		serviceIDs = append(serviceIDs, svc.ID)
+	}
+	booked, err := resolveServices(s.links, barber.ID, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := timeRange{start: start, end: start.Add(booked.duration)}
+	if entry.WindowStart != nil && entry.WindowEnd != nil {
+		dayStart := s.calendar.startOfDay(start)
+		from, _ := parseClock(*entry.WindowStart)
+		to, _ := parseClock(*entry.WindowEnd)
+		if !(timeRange{start: atClock(dayStart, from), end: atClock(dayStart, to)}).contains(slot) {
+			return nil, errors.New("слот вне удобного клиенту времени")
+		}
+	}
+	if err := s.calendar.checkWorkingTime(barber, slot); err != nil {
+		return nil, err
+	}
+
+	buffer := booked.bufferFor(barber)
+	hold := models.SlotHold{
+		BarberID:      barber.ID,
+		ClientID:      entry.ClientID,
+		StartsAt:      slot.start,
+		EndsAt:        slot.end,
+		BufferMinutes: int(buffer / time.Minute),
+		Services:      booked.services,
+		Price:         booked.price,
+		Status:        models.HoldActive,
+		Kind:          models.HoldWaitlistOffer,
+	}
+	err = s.appointments.Transaction(func(tx repository.AppointmentsRepository) error {
+		if err := tx.LockBarber(barber.ID); err != nil {
+			return err
+		}
+
+		now := s.calendar.now()
+		isFree, err := slotIsFree(tx, barber.ID, slot.start, slot.end.Add(buffer), now, 0, 0)
+		if err != nil {
+			return err
+		}
+		if !isFree {
+			return ErrSlotTaken
+		}
+
+		hold.ExpiresAt = now.Add(waitlistOfferTTL)
+		if err := tx.CreateHold(&hold); err != nil {
+			return err
+		}
+
+		// Бронь без предложенной заявки занимала бы слот впустую, поэтому заявка меняет статус в той же транзакции.
+		updated, err := tx.Waitlist().UpdateStatus(entry.ID, models.WaitlistWaiting, map[string]any{
+			"status":  models.WaitlistOffered,
+			"hold_id": hold.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrStatusChanged
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
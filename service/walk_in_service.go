@@ -0,0 +1,408 @@
+package service
+
+import (
+	"barber-backend-api/internal/events"
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type WalkInService interface {
+	Join(req *models.WalkInJoinReqDTO) (*models.WalkInEntry, error)
+	GetQueue() ([]models.WalkInEntry, error)
+	GetByID(id uint) (*models.WalkInEntry, error)
+	Move(id uint, position int) ([]models.WalkInEntry, error)
+	Leave(id uint) error
+	CallNext(barberID uint) (*models.Appointments, error)
+}
+
+type walkInService struct {
+	logger       *slog.Logger
+	service      repository.WalkInRepository
+	appointments repository.AppointmentsRepository
+	barbers      repository.BarbersRepository
+	clients      repository.ClientsRepository
+	links        repository.BarberServicesRepository
+	calendar     *WorkCalendar
+	events       *events.Bus
+}
+
+func NewWalkInService(logger *slog.Logger, service repository.WalkInRepository, appointments repository.AppointmentsRepository, barbers repository.BarbersRepository, clients repository.ClientsRepository, links repository.BarberServicesRepository, calendar *WorkCalendar, bus *events.Bus) WalkInService {
+	return &walkInService{logger: logger, service: service, appointments: appointments, barbers: barbers, clients: clients, links: links, calendar: calendar, events: bus}
+}
+
+// Join ставит клиента в конец живой очереди к конкретному или любому парикмахеру.
+// Для очереди к любому парикмахеру услуги должен оказывать хотя бы один из них.
+func (s *walkInService) Join(req *models.WalkInJoinReqDTO) (*models.WalkInEntry, error) {
+	if _, err := s.clients.GetClientByID(req.ClientID); err != nil {
+		return nil, errors.New("клиент не найден")
+	}
+
+	var services []models.Service
+	if req.BarberID != nil {
+		isExist, err := s.barbers.Exists(*req.BarberID)
+		if err != nil {
+			return nil, err
+		}
+		if !isExist {
+			return nil, errors.New("парикмахер не найден")
+		}
+
+		booked, err := resolveServices(s.links, *req.BarberID, req.ServiceIDs)
+		if err != nil {
+			return nil, err
+		}
+		services = booked.services
+	} else {
+		var err error
+		services, err = s.anyBarberServices(req.ServiceIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry := models.WalkInEntry{
+		ClientID: req.ClientID,
+		BarberID: req.BarberID,
+		Services: services,
+		Status:   models.WalkInWaiting,
+	}
+	created, err := s.service.Create(&entry)
+	if err != nil {
+		s.logger.Error("ошибка добавления в живую очередь",
+			"op", "service.walk_in.Join",
+			"client_id", req.ClientID,
+			"error", err,
+		)
+		return nil, err
+	}
+	if !created {
+		return nil, errors.New("клиент уже стоит в очереди")
+	}
+
+	s.logger.Info("клиент встал в живую очередь",
+		"op", "service.walk_in.Join",
+		"id", entry.ID,
+		"client_id", entry.ClientID,
+		"position", entry.Position,
+	)
+	return s.GetByID(entry.ID)
+}
+
+func (s *walkInService) anyBarberServices(ids []uint) ([]models.Service, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	barbers, err := s.barbers.GetAllBarbers()
+	if err != nil {
+		return nil, err
+	}
+
+	lastErr := ErrServiceNotOffered
+	for _, barber := range barbers {
+		booked, err := resolveServices(s.links, barber.ID, ids)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return booked.services, nil
+	}
+	return nil, lastErr
+}
+
+// GetQueue возвращает ожидающих клиентов по порядку с оценкой ожидания.
+func (s *walkInService) GetQueue() ([]models.WalkInEntry, error) {
+	entries, err := s.service.GetWaiting()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.estimate(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *walkInService) GetByID(id uint) (*models.WalkInEntry, error) {
+	entry, err := s.service.GetByID(id)
+	if err != nil {
+		return nil, errors.New("клиент в очереди не найден")
+	}
+	if entry.Status != models.WalkInWaiting {
+		return entry, nil
+	}
+
+	queue, err := s.GetQueue()
+	if err != nil {
+		return nil, err
+	}
+	for _, waiting := range queue {
+		if waiting.ID == entry.ID {
+			return &waiting, nil
+		}
+	}
+	return entry, nil
+}
+
+// Move меняет место клиента в очереди и возвращает очередь в новом порядке.
+func (s *walkInService) Move(id uint, position int) ([]models.WalkInEntry, error) {
+	if position < 1 {
+		return nil, errors.New("позиция в очереди начинается с 1")
+	}
+
+	if err := s.service.Move(id, position); err != nil {
+		return nil, errors.New("клиент не стоит в очереди")
+	}
+
+	s.logger.Info("порядок живой очереди изменён",
+		"op", "service.walk_in.Move",
+		"id", id,
+		"position", position,
+	)
+	return s.GetQueue()
+}
+
+func (s *walkInService) Leave(id uint) error {
+	updated, err := s.service.UpdateStatus(id, models.WalkInWaiting, map[string]any{"status": models.WalkInLeft})
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("клиент не стоит в очереди")
+	}
+
+	s.logger.Info("клиент покинул живую очередь",
+		"op", "service.walk_in.Leave",
+		"id", id,
+	)
+	return nil
+}
+
+// CallNext вызывает к парикмахеру первого подходящего клиента из очереди и создаёт запись
+// на визит, начинающийся сейчас. Клиенты, чьи услуги парикмахер не оказывает, пропускаются.
+func (s *walkInService) CallNext(barberID uint) (*models.Appointments, error) {
+	barber, err := s.barbers.GetBarberByID(barberID)
+	if err != nil {
+		return nil, errors.New("парикмахер не найден")
+	}
+
+	entries, err := s.service.GetWaiting()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.BarberID != nil && *entry.BarberID != barberID {
+			continue
+		}
+
+		booked, err := resolveServices(s.links, barberID, serviceIDsOf(entry.Services))
+		if err != nil {
+			continue
+		}
+
+		now := s.calendar.now().Truncate(time.Minute)
+		claimed, err := s.service.UpdateStatus(entry.ID, models.WalkInWaiting, map[string]any{
+			"status":           models.WalkInCalled,
+			"called_barber_id": barberID,
+			"called_at":        now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			continue
+		}
+
+		appointment, err := s.startVisit(barber, entry, booked, now)
+		if err != nil {
+			if _, revertErr := s.service.UpdateStatus(entry.ID, models.WalkInCalled, map[string]any{
+				"status":           models.WalkInWaiting,
+				"called_barber_id": nil,
+				"called_at":        nil,
+			}); revertErr != nil {
+				s.logger.Error("ошибка возврата клиента в очередь",
+					"op", "service.walk_in.CallNext",
+					"id", entry.ID,
+					"error", revertErr,
+				)
+			}
+			return nil, err
+		}
+
+		if _, err := s.service.UpdateStatus(entry.ID, models.WalkInCalled, map[string]any{"appointment_id": appointment.ID}); err != nil {
+			s.logger.Error("ошибка привязки записи к очереди",
+				"op", "service.walk_in.CallNext",
+				"id", entry.ID,
+				"appointment_id", appointment.ID,
+				"error", err,
+			)
+		}
+
+		s.logger.Info("клиент из живой очереди вызван",
+			"op", "service.walk_in.CallNext",
+			"id", entry.ID,
+			"barber_id", barberID,
+			"appointment_id", appointment.ID,
+		)
+		s.calendar.localize(appointment)
+		return appointment, nil
+	}
+
+	return nil, errors.New("в очереди нет клиентов для этого парикмахера")
+}
+
+// startVisit создаёт запись со статусом checked_in: клиент уже в салоне и садится в кресло.
+func (s *walkInService) startVisit(barber *models.Barber, entry *models.WalkInEntry, booked *bookingServices, now time.Time) (*models.Appointments, error) {
+	slot := timeRange{start: now, end: now.Add(booked.duration)}
+	if err := s.calendar.checkWorkingTime(barber, slot); err != nil {
+		return nil, err
+	}
+	buffer := booked.bufferFor(barber)
+
+	appointment := models.Appointments{
+		BarberID:      barber.ID,
+		ClientID:      entry.ClientID,
+		StartsAt:      slot.start,
+		EndsAt:        slot.end,
+		Services:      booked.services,
+		Price:         booked.price,
+		BufferMinutes: int(buffer / time.Minute),
+		Status:        models.AppointmentCheckedIn,
+	}
+	err := s.appointments.Transaction(func(tx repository.AppointmentsRepository) error {
+		if err := tx.LockBarber(barber.ID); err != nil {
+			return err
+		}
+
+		isFree, err := slotIsFree(tx, barber.ID, slot.start, slot.end.Add(buffer), now, 0, 0)
+		if err != nil {
+			return err
+		}
+		if !isFree {
+			return fmt.Errorf("%w: до следующей записи не хватает времени", ErrSlotTaken)
+		}
+
+		return createAppointment(tx, &appointment, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishAppointment(s.events, events.AppointmentCreated, &appointment)
+	return &appointment, nil
+}
+
+// walkInLane — расписание одного парикмахера на сегодня для оценки ожидания.
+type walkInLane struct {
+	barber  models.Barber
+	working []timeRange
+	busy    []timeRange
+}
+
+// estimate проставляет ожидающим оценку ожидания: очередь раздаётся по порядку,
+// каждому клиенту достаётся парикмахер, который раньше всех освободится на нужное время
+// с учётом уже назначенных записей и клиентов, стоящих впереди.
+func (s *walkInService) estimate(entries []models.WalkInEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	barbers, err := s.barbers.GetAllBarbers()
+	if err != nil {
+		return err
+	}
+
+	now := s.calendar.now()
+	dayStart := s.calendar.startOfDay(now)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	lanes := make([]walkInLane, 0, len(barbers))
+	for _, barber := range barbers {
+		working, err := s.calendar.workingRanges(&barber, dayStart)
+		if err != nil {
+			return err
+		}
+		busy, err := occupiedRanges(s.appointments, barber.ID, dayStart, dayEnd, now)
+		if err != nil {
+			return err
+		}
+		lanes = append(lanes, walkInLane{barber: barber, working: working, busy: busy})
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		serviceIDs := serviceIDsOf(entry.Services)
+
+		var best *walkInLane
+		var bestStart time.Time
+		var bestOccupied time.Duration
+		for j := range lanes {
+			lane := &lanes[j]
+			if entry.BarberID != nil && *entry.BarberID != lane.barber.ID {
+				continue
+			}
+
+			booked, err := resolveServices(s.links, lane.barber.ID, serviceIDs)
+			if err != nil {
+				continue
+			}
+			occupied := booked.duration + booked.bufferFor(&lane.barber)
+
+			start, ok := lane.earliestStart(now, booked.duration, occupied)
+			if ok && (best == nil || start.Before(bestStart)) {
+				best, bestStart, bestOccupied = lane, start, occupied
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		best.busy = append(best.busy, timeRange{start: bestStart, end: bestStart.Add(bestOccupied)})
+		wait := int(bestStart.Sub(now) / time.Minute)
+		barberID := best.barber.ID
+		entry.EstimatedWaitMinutes = &wait
+		entry.EstimatedBarberID = &barberID
+	}
+	return nil
+}
+
+// earliestStart ищет самое раннее начало не раньше now, при котором услуга длительностью duration
+// укладывается в рабочее время, а [start, start+occupied) не пересекается с занятым временем.
+func (l *walkInLane) earliestStart(now time.Time, duration, occupied time.Duration) (time.Time, bool) {
+	for _, working := range l.working {
+		start := working.start
+		if start.Before(now) {
+			start = now
+		}
+
+		for !start.Add(duration).After(working.end) {
+			candidate := timeRange{start: start, end: start.Add(occupied)}
+			moved := false
+			for _, busy := range l.busy {
+				if busy.overlaps(candidate) {
+					start = busy.end
+					moved = true
+					break
+				}
+			}
+			if !moved {
+				return start, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func serviceIDsOf(services []models.Service) []uint {
+	ids := make([]uint, 0, len(services))
+	for _, svc := range services {
+		ids = append(ids, svc.ID)
+	}
+	return ids
+}
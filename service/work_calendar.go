@@ -0,0 +1,324 @@
+package service
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/repository"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+func (r timeRange) overlaps(other timeRange) bool {
+	return r.start.Before(other.end) && other.start.Before(r.end)
+}
+
+func (r timeRange) contains(other timeRange) bool {
+	return !other.start.Before(r.start) && !other.end.After(r.end)
+}
+
+func (r timeRange) String() string {
+	end := r.end.In(r.start.Location())
+	endClock := end.Format("15:04")
+	if end.Day() != r.start.Day() {
+		endClock = "24:00"
+	}
+	return r.start.Format("15:04") + "-" + endClock
+}
+
+// WorkCalendar отвечает на вопрос, когда парикмахер работает в конкретный день.
+// step — шаг сетки, по которой выдаются слоты и округляется начало записи.
+type WorkCalendar struct {
+	loc       *time.Location
+	step      time.Duration
+	schedules repository.SchedulesRepository
+	timeOffs  repository.TimeOffRepository
+	holidays  repository.HolidaysRepository
+}
+
+func NewWorkCalendar(loc *time.Location, step time.Duration, schedules repository.SchedulesRepository, timeOffs repository.TimeOffRepository, holidays repository.HolidaysRepository) *WorkCalendar {
+	return &WorkCalendar{loc: loc, step: step, schedules: schedules, timeOffs: timeOffs, holidays: holidays}
+}
+
+// now возвращает текущее время в часовом поясе салона.
+func (c *WorkCalendar) now() time.Time {
+	return time.Now().In(c.loc)
+}
+
+// startOfDay возвращает полночь дня, в который попадает t, по часовому поясу салона.
+func (c *WorkCalendar) startOfDay(t time.Time) time.Time {
+	t = t.In(c.loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.loc)
+}
+
+// alignToGrid округляет t вверх до ближайшей границы сетки, отсчитывая от полуночи.
+func (c *WorkCalendar) alignToGrid(t time.Time) time.Time {
+	dayStart := c.startOfDay(t)
+	offset := t.Sub(dayStart)
+	if rem := offset % c.step; rem != 0 {
+		offset += c.step - rem
+	}
+	return dayStart.Add(offset)
+}
+
+func (c *WorkCalendar) workingRanges(barber *models.Barber, day time.Time) ([]timeRange, error) {
+	dayStart := c.startOfDay(day)
+
+	schedule, err := c.schedules.GetEffective(barber.ID, dayStart)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranges []timeRange
+	if schedule == nil {
+		// Без графика парикмахер работает по будням в часы из профиля.
+		if dayStart.Weekday() != time.Saturday && dayStart.Weekday() != time.Sunday {
+			ranges = append(ranges, timeRange{
+				start: atClock(dayStart, barber.WorkHoursStart*60),
+				end:   atClock(dayStart, barber.WorkHoursEnd*60),
+			})
+		}
+	} else {
+		for _, interval := range schedule.Intervals {
+			if time.Weekday(interval.Weekday) != dayStart.Weekday() {
+				continue
+			}
+			start, err := parseClock(interval.Start)
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseClock(interval.End)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, timeRange{
+				start: atClock(dayStart, start),
+				end:   atClock(dayStart, end),
+			})
+		}
+	}
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Before(ranges[j].start)
+	})
+
+	closures, err := c.closures(dayStart)
+	if err != nil {
+		return nil, err
+	}
+
+	timeOffs, err := c.timeOffs.GetActiveInRange(barber.ID, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	blocked := make([]timeRange, 0, len(closures)+len(timeOffs))
+	for _, closure := range closures {
+		blocked = append(blocked, closure.period)
+	}
+	for _, off := range timeOffs {
+		blocked = append(blocked, timeRange{start: off.Start, end: off.End})
+	}
+
+	return subtractRanges(ranges, blocked), nil
+}
+
+func (c *WorkCalendar) checkWorkingTime(barber *models.Barber, slot timeRange) error {
+	timeOffs, err := c.timeOffs.GetActiveInRange(barber.ID, slot.start, slot.end)
+	if err != nil {
+		return err
+	}
+	if len(timeOffs) > 0 {
+		off := timeOffs[0]
+		return fmt.Errorf("парикмахер недоступен с %s по %s (%s)",
+			off.Start.In(c.loc).Format("2006-01-02 15:04"), off.End.In(c.loc).Format("2006-01-02 15:04"), off.Kind)
+	}
+
+	closures, err := c.closures(slot.start)
+	if err != nil {
+		return err
+	}
+	for _, closure := range closures {
+		if closure.period.overlaps(slot) {
+			return fmt.Errorf("салон закрыт %s: %s", closure.period, closure.name)
+		}
+	}
+
+	ranges, err := c.workingRanges(barber, slot.start)
+	if err != nil {
+		return err
+	}
+
+	if len(ranges) == 0 {
+		return errors.New("парикмахер не работает в этот день")
+	}
+
+	for _, r := range ranges {
+		if r.contains(slot) {
+			return nil
+		}
+	}
+
+	hours := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		hours = append(hours, r.String())
+	}
+	return fmt.Errorf("парикмахер работает в этот день: %s", strings.Join(hours, ", "))
+}
+
+// freeSlots нарезает свободное рабочее время дня на слоты длиной duration,
+// начинающиеся на сетке календаря и не раньше notBefore. После каждого слота
+// должно оставаться buffer свободного времени.
+func (c *WorkCalendar) freeSlots(barber *models.Barber, day time.Time, busy []timeRange, duration, buffer time.Duration, notBefore time.Time) ([]timeRange, error) {
+	ranges, err := c.workingRanges(barber, day)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []timeRange
+	for _, working := range ranges {
+		// Сама услуга должна закончиться до конца смены, а пауза после неё
+		// может выйти за смену, но не должна задевать следующую запись.
+		extended := timeRange{start: working.start, end: working.end.Add(buffer)}
+		for _, r := range subtractRanges([]timeRange{extended}, busy) {
+			for start := c.alignToGrid(r.start); ; start = start.Add(c.step) {
+				end := start.Add(duration)
+				if end.After(working.end) || end.Add(buffer).After(r.end) {
+					break
+				}
+				if start.Before(notBefore) {
+					continue
+				}
+				slots = append(slots, timeRange{start: start, end: end})
+			}
+		}
+	}
+	return slots, nil
+}
+
+type closure struct {
+	name   string
+	period timeRange
+}
+
+// closures возвращает периоды, когда салон закрыт в указанный день из-за праздников.
+func (c *WorkCalendar) closures(day time.Time) ([]closure, error) {
+	dayStart := c.startOfDay(day)
+
+	holidays, err := c.holidays.GetByDate(dayStart)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]closure, 0, len(holidays))
+	for _, h := range holidays {
+		period := timeRange{start: dayStart, end: dayStart.AddDate(0, 0, 1)}
+		if h.ClosedFrom != nil {
+			from, err := parseClock(*h.ClosedFrom)
+			if err != nil {
+				return nil, err
+			}
+			period.start = atClock(dayStart, from)
+		}
+		if h.ClosedTo != nil {
+			to, err := parseClock(*h.ClosedTo)
+			if err != nil {
+				return nil, err
+			}
+			period.end = atClock(dayStart, to)
+		}
+		result = append(result, closure{name: h.Name, period: period})
+	}
+	return result, nil
+}
+
+// busyRanges возвращает занятые интервалы записей вместе с паузой после каждой из них.
+func busyRanges(appointments []models.Appointments) []timeRange {
+	ranges := make([]timeRange, 0, len(appointments))
+	for _, v := range appointments {
+		end := v.EndsAt.Add(time.Duration(v.BufferMinutes) * time.Minute)
+		ranges = append(ranges, timeRange{start: v.StartsAt, end: end})
+	}
+	return ranges
+}
+
+// subtractRanges вырезает из отсортированных интервалов ranges все пересечения с blocked.
+func subtractRanges(ranges, blocked []timeRange) []timeRange {
+	result := ranges
+	for _, b := range blocked {
+		next := make([]timeRange, 0, len(result))
+		for _, r := range result {
+			if !r.overlaps(b) {
+				next = append(next, r)
+				continue
+			}
+			if r.start.Before(b.start) {
+				next = append(next, timeRange{start: r.start, end: b.start})
+			}
+			if b.end.Before(r.end) {
+				next = append(next, timeRange{start: b.end, end: r.end})
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+// localize переводит время записи в часовой пояс салона, чтобы в ответах API было его смещение.
+func (c *WorkCalendar) localize(appointments ...*models.Appointments) {
+	for _, a := range appointments {
+		if a == nil {
+			continue
+		}
+		a.StartsAt = a.StartsAt.In(c.loc)
+		a.EndsAt = a.EndsAt.In(c.loc)
+	}
+}
+
+func (c *WorkCalendar) localizeAll(appointments []models.Appointments) []models.Appointments {
+	for i := range appointments {
+		c.localize(&appointments[i])
+	}
+	return appointments
+}
+
+// atClock возвращает момент minutes минут от полуночи дня dayStart по настенным часам,
+// поэтому переход на летнее время не сдвигает рабочие часы.
+func atClock(dayStart time.Time, minutes int) time.Time {
+	return time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, minutes, 0, 0, dayStart.Location())
+}
+
+// parseClock переводит время вида "HH:MM" в минуты от начала суток, допуская "24:00".
+func parseClock(value string) (int, error) {
+	hh, mm, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, fmt.Errorf("неправильный формат времени %q, нужен HH:MM", value)
+	}
+
+	hours, err := strconv.Atoi(hh)
+	if err != nil || len(mm) != 2 {
+		return 0, fmt.Errorf("неправильный формат времени %q, нужен HH:MM", value)
+	}
+	minutes, err := strconv.Atoi(mm)
+	if err != nil {
+		return 0, fmt.Errorf("неправильный формат времени %q, нужен HH:MM", value)
+	}
+
+	if hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("время %q вне суток", value)
+	}
+	return hours*60 + minutes, nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
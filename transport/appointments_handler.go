@@ -3,6 +3,7 @@ package transport
 import (
 	"barber-backend-api/internal/models"
 	"barber-backend-api/service"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -24,8 +25,16 @@ func (h *AppointmentsHandler) RegisterRoutes(r *gin.Engine) {
 		appointments.GET("/", h.GetAllAppointments)
 		appointments.GET("/:id", h.GetAppointmentByID)
 		appointments.GET("/barbers/:barbersID", h.GetAllAppointmentsByBarberID)
+		appointments.GET("/series/:seriesID", h.GetSeries)
 		appointments.POST("/", h.CreateAppointment)
-		appointments.PATCH("/:id", h.Update)
+		appointments.POST("/next-available", h.BookNextAvailable)
+		appointments.POST("/:id/approve", h.Approve)
+		appointments.POST("/:id/confirm", h.Confirm)
+		appointments.POST("/:id/check-in", h.CheckIn)
+		appointments.POST("/:id/complete", h.Complete)
+		appointments.POST("/:id/cancel", h.Cancel)
+		appointments.POST("/:id/no-show", h.NoShow)
+		appointments.POST("/:id/reschedule", h.Reschedule)
 		appointments.DELETE("/:id", h.Delete)
 	}
 }
@@ -82,15 +91,65 @@ func (h *AppointmentsHandler) CreateAppointment(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.CreateAppointment(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if req.StartsAt == "" && req.Time != "" {
+		c.Header("Deprecation", "true")
+		c.Header("Warning", `299 - "поле time устарело, используйте starts_at"`)
+	}
+
+	if req.Recurrence != "" {
+		series, err := h.service.CreateSeries(&req)
+		if err != nil {
+			c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, series)
+		return
+	}
+
+	appointment, err := h.service.CreateAppointment(&req)
+	if err != nil {
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
+	if appointment.Status == models.AppointmentPendingApproval {
+		c.JSON(http.StatusAccepted, gin.H{"message": "pending approval", "id": appointment.ID})
+		return
+	}
 	c.JSON(http.StatusCreated, gin.H{"message": "created"})
 }
 
-func (h *AppointmentsHandler) Update(c *gin.Context) {
+func (h *AppointmentsHandler) BookNextAvailable(c *gin.Context) {
+	var req models.AppointmentsNextAvailableReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	appointment, err := h.service.BookNextAvailable(&req)
+	if err != nil {
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, appointment)
+}
+
+func (h *AppointmentsHandler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AppointmentsHandler) Reschedule(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
@@ -98,30 +157,138 @@ func (h *AppointmentsHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var req models.AppointmentsUpdateReqDTO
+	var req models.AppointmentsRescheduleReqDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := h.service.Update(uint(id), req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if scope := c.Query("scope"); scope != "" && scope != models.SeriesScopeThis {
+		series, err := h.service.RescheduleSeries(uint(id), scope, &req)
+		if err != nil {
+			c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, series)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "status updated"})
+	appointment, err := h.service.Reschedule(uint(id), &req)
+	if err != nil {
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
 }
 
-func (h *AppointmentsHandler) Delete(c *gin.Context) {
+func (h *AppointmentsHandler) Approve(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
 		return
 	}
-	if err := h.service.Delete(uint(id)); err != nil {
+
+	appointment, err := h.service.Approve(uint(id))
+	if err != nil {
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+func (h *AppointmentsHandler) Confirm(c *gin.Context) {
+	h.changeStatus(c, models.AppointmentConfirmed)
+}
+
+func (h *AppointmentsHandler) CheckIn(c *gin.Context) {
+	h.changeStatus(c, models.AppointmentCheckedIn)
+}
+
+func (h *AppointmentsHandler) Complete(c *gin.Context) {
+	h.changeStatus(c, models.AppointmentCompleted)
+}
+
+func (h *AppointmentsHandler) Cancel(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	if scope := c.Query("scope"); scope != "" && scope != models.SeriesScopeThis {
+		series, err := h.service.CancelSeries(uint(id), scope)
+		if err != nil {
+			c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, series)
+		return
+	}
+
+	appointment, err := h.service.Cancel(uint(id))
+	if err != nil {
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+func (h *AppointmentsHandler) GetSeries(c *gin.Context) {
+	idStr := c.Param("seriesID")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	series, err := h.service.GetSeries(uint(id))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.Status(http.StatusNoContent)
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (h *AppointmentsHandler) NoShow(c *gin.Context) {
+	h.changeStatus(c, models.AppointmentNoShow)
+}
+
+func (h *AppointmentsHandler) changeStatus(c *gin.Context, status string) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	appointment, err := h.service.ChangeStatus(uint(id), status)
+	if err != nil {
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+func appointmentErrorStatus(err error) int {
+	if errors.Is(err, service.ErrSlotTaken) || errors.Is(err, service.ErrInvalidTransition) || errors.Is(err, service.ErrStatusChanged) ||
+		errors.Is(err, service.ErrHoldNotActive) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, service.ErrHoldExpired) {
+		return http.StatusGone
+	}
+	if errors.Is(err, service.ErrReviewExists) || errors.Is(err, service.ErrReplyExists) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, service.ErrClientFlagged) || errors.Is(err, service.ErrNotAppointmentOwner) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
 }
@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"barber-backend-api/service"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AvailabilityHandler struct {
+	service service.AvailabilityService
+}
+
+func NewAvailabilityHandler(service service.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{service: service}
+}
+
+func (h *AvailabilityHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/barbers/:id/availability", h.GetBarberAvailability)
+
+	availability := r.Group("/availability")
+	{
+		availability.GET("/", h.GetDayAvailability)
+		availability.GET("/next", h.GetNextAvailable)
+	}
+}
+
+func (h *AvailabilityHandler) GetBarberAvailability(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	serviceIDs, err := parseIDList(c.Query("service_ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	availability, err := h.service.GetBarberAvailability(uint(id), c.Query("from"), c.Query("to"), serviceIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+func (h *AvailabilityHandler) GetDayAvailability(c *gin.Context) {
+	serviceIDs, err := parseIDList(c.Query("service_ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	availability, err := h.service.GetDayAvailability(c.Query("date"), serviceIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+func (h *AvailabilityHandler) GetNextAvailable(c *gin.Context) {
+	var minRating float64
+	if raw := c.Query("min_rating"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный min_rating"})
+			return
+		}
+		minRating = v
+	}
+
+	serviceIDs, err := parseIDList(c.Query("service_ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slot, err := h.service.GetNextAvailable(c.Query("after"), minRating, serviceIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, slot)
+}
+
+// parseIDList разбирает список идентификаторов вида "1,2,3".
+func parseIDList(raw string) ([]uint, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	ids := make([]uint, 0, len(parts))
+	for _, p := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(p), 10, 64)
+		if err != nil {
+			return nil, errors.New("некорректный список идентификаторов")
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
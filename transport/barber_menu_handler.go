@@ -0,0 +1,116 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BarberMenuHandler struct {
+	logger  *slog.Logger
+	service service.BarberMenuService
+}
+
+func NewBarberMenuHandler(logger *slog.Logger, service service.BarberMenuService) *BarberMenuHandler {
+	return &BarberMenuHandler{logger: logger, service: service}
+}
+
+func (h *BarberMenuHandler) RegisterRoutes(r *gin.Engine) {
+
+	menu := r.Group("/barbers/:id/services")
+	{
+		menu.GET("/", h.GetMenu)
+		menu.PUT("/:serviceID", h.SetService)
+		menu.DELETE("/:serviceID", h.RemoveService)
+	}
+}
+
+func (h *BarberMenuHandler) GetMenu(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	menu, err := h.service.GetMenu(barberID)
+	if err != nil {
+		h.logger.Error("сервисная ошибка GetMenu", "error", err, "barber_id", barberID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, menu)
+}
+
+func (h *BarberMenuHandler) SetService(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	serviceID, ok := h.parseID(c, "serviceID")
+	if !ok {
+		return
+	}
+
+	var req models.BarberServiceReqDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Warn("ошибка валидации SetService", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	item, err := h.service.SetService(barberID, serviceID, &req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка SetService", "error", err, "barber_id", barberID, "service_id", serviceID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ SetService", "method", method, "uri", uri, "status_code", http.StatusOK, "entity_id", serviceID)
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *BarberMenuHandler) RemoveService(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	serviceID, ok := h.parseID(c, "serviceID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveService(barberID, serviceID); err != nil {
+		h.logger.Error("сервисная ошибка RemoveService", "error", err, "barber_id", barberID, "service_id", serviceID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ RemoveService", "method", method, "uri", uri, "status_code", http.StatusNoContent, "entity_id", serviceID)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BarberMenuHandler) parseID(c *gin.Context, param string) (uint, bool) {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("некорректный идентификатор", "param", param, "id", idStr, "method", c.Request.Method, "uri", c.FullPath())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BarberProfileHandler struct {
+	logger  *slog.Logger
+	service service.BarberProfileService
+}
+
+func NewBarberProfileHandler(logger *slog.Logger, service service.BarberProfileService) *BarberProfileHandler {
+	return &BarberProfileHandler{logger: logger, service: service}
+}
+
+func (h *BarberProfileHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/barbers/:id/profile", h.GetProfile)
+}
+
+func (h *BarberProfileHandler) GetProfile(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("некорректный идентификатор", "id", idStr, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	var reviews int
+	if raw := c.Query("reviews"); raw != "" {
+		reviews, err = strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "некорректное число отзывов"})
+			return
+		}
+	}
+
+	profile, err := h.service.GetProfile(uint(id), reviews)
+	if err != nil {
+		h.logger.Error("сервисная ошибка GetBarberProfile", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
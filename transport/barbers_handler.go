@@ -69,16 +69,31 @@ func (h *BarberHandler) Update(c *gin.Context) {
 	}
 	id := uint(idUint64)
 
-	var barberInput models.BarbersCreateReqDTO
+	var barberInput models.BarbersUpdateReqDTO
 	if err := c.ShouldBindJSON(&barberInput); err != nil {
 		h.logger.Warn("ошибка валидации Update", "reason", err.Error(), "id", id, "body_valid", false, "method", method, "uri", uri)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	barber := models.Barber{
-		FullName: barberInput.FullName,
+	if (barberInput.WorkHoursStart != nil && *barberInput.WorkHoursStart <= 0) ||
+		(barberInput.WorkHoursEnd != nil && *barberInput.WorkHoursEnd <= 0) {
+		h.logger.Warn("ошибка валидации Update", "reason", "некорректные часы работы", "id", id, "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "часы работы должны быть больше нуля"})
+		return
+	}
+
+	var barber models.Barber
+	if barberInput.FullName != nil {
+		barber.FullName = *barberInput.FullName
 	}
+	if barberInput.WorkHoursStart != nil {
+		barber.WorkHoursStart = *barberInput.WorkHoursStart
+	}
+	if barberInput.WorkHoursEnd != nil {
+		barber.WorkHoursEnd = *barberInput.WorkHoursEnd
+	}
+	barber.BufferMinutes = barberInput.BufferMinutes
 	barberReq, err := h.service.Update(id, barber)
 	if err != nil {
 		h.logger.Error("сервисная ошибка Update", "error", err, "id", id, "method", method, "uri", uri)
@@ -89,8 +104,14 @@ func (h *BarberHandler) Update(c *gin.Context) {
 	h.logger.Info("успешный ответ Update", "method", method, "uri", uri, "status_code", http.StatusOK, "entity_id", barberReq.ID)
 
 	barberRes := models.BarberResDTO{
-		FullName:  barberReq.FullName,
-		AvgRating: barberReq.AvgRating,
+		ID:             barberReq.ID,
+		FullName:       barberReq.FullName,
+		WorkHoursStart: barberReq.WorkHoursStart,
+		WorkHoursEnd:   barberReq.WorkHoursEnd,
+		AvgRating:      barberReq.AvgRating,
+	}
+	if barberReq.BufferMinutes != nil {
+		barberRes.BufferMinutes = *barberReq.BufferMinutes
 	}
 
 	c.JSON(http.StatusOK, barberRes)
@@ -102,7 +123,8 @@ func (h *BarberHandler) GetAll(c *gin.Context) {
 
 	h.logger.Info("запрос GetAll начат", "method", method, "uri", uri)
 
-	barbers, err := h.service.GetAll()
+	// ?include=services добавляет к каждому парикмахеру его меню услуг с ценами
+	barbers, err := h.service.GetAll(c.Query("include") == "services")
 	if err != nil {
 		h.logger.Error("сервисная ошибка GetAll", "error", err, "method", method, "uri", uri)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -21,6 +21,9 @@ func (h *ClientsHandler) RegisterRoutes(r *gin.Engine) {
 	client := r.Group("/clients")
 	{
 		client.GET("/", h.GetAllClients)
+		client.GET("/flagged", h.GetFlagged)
+		client.GET("/:id/no-shows", h.GetNoShows)
+		client.DELETE("/:id/flag", h.ClearFlag)
 		client.GET("/:id", h.GetClientByID)
 		client.POST("/", h.AddClient)
 		client.PATCH("/:id", h.Update)
@@ -108,3 +111,47 @@ func (h *ClientsHandler) Delete(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (h *ClientsHandler) GetFlagged(c *gin.Context) {
+	clients, err := h.service.GetFlagged()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, clients)
+}
+
+func (h *ClientsHandler) GetNoShows(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	res, err := h.service.GetNoShows(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *ClientsHandler) ClearFlag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	res, err := h.service.ClearFlag(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"barber-backend-api/internal/events"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// heartbeatInterval — как часто в поток пишется комментарий, чтобы прокси не закрывали простаивающее соединение.
+const heartbeatInterval = 15 * time.Second
+
+type EventsHandler struct {
+	logger *slog.Logger
+	bus    *events.Bus
+}
+
+func NewEventsHandler(logger *slog.Logger, bus *events.Bus) *EventsHandler {
+	return &EventsHandler{logger: logger, bus: bus}
+}
+
+func (h *EventsHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/appointments/events", h.Stream)
+}
+
+// Stream отдаёт события записей как Server-Sent Events. Параметр barber_id оставляет
+// только события одного парикмахера. Имя события SSE совпадает с полем type.
+func (h *EventsHandler) Stream(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	var barberID uint
+	if raw := c.Query("barber_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор парикмахера"})
+			return
+		}
+		barberID = uint(id)
+	}
+
+	sub := h.bus.Subscribe(barberID)
+	defer sub.Close()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	// Заголовки отправляются сразу, чтобы клиент знал о подписке ещё до первого события.
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	h.logger.Info("подписка на события записей", "barber_id", barberID, "method", method, "uri", uri)
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-sub.C:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+
+	h.logger.Info("подписка на события записей закрыта", "barber_id", barberID, "method", method, "uri", uri)
+}
@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HoldsHandler struct {
+	logger  *slog.Logger
+	service service.HoldService
+}
+
+func NewHoldsHandler(logger *slog.Logger, service service.HoldService) *HoldsHandler {
+	return &HoldsHandler{logger: logger, service: service}
+}
+
+func (h *HoldsHandler) RegisterRoutes(r *gin.Engine) {
+
+	holds := r.Group("/holds")
+	{
+		holds.POST("/", h.Create)
+		holds.GET("/:id", h.GetByID)
+		holds.POST("/:id/confirm", h.Confirm)
+		holds.DELETE("/:id", h.Release)
+	}
+}
+
+func (h *HoldsHandler) Create(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	var req models.SlotHoldCreateReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации CreateHold", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hold, err := h.service.Create(&req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка CreateHold", "error", err, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ CreateHold", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", hold.ID)
+	c.JSON(http.StatusCreated, hold)
+}
+
+func (h *HoldsHandler) GetByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	hold, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hold)
+}
+
+func (h *HoldsHandler) Confirm(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	appointment, err := h.service.Confirm(id)
+	if err != nil {
+		h.logger.Error("сервисная ошибка ConfirmHold", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ ConfirmHold", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", appointment.ID)
+	c.JSON(http.StatusCreated, appointment)
+}
+
+func (h *HoldsHandler) Release(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Release(id); err != nil {
+		h.logger.Error("сервисная ошибка ReleaseHold", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ ReleaseHold", "method", method, "uri", uri, "status_code", http.StatusNoContent, "entity_id", id)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *HoldsHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("некорректный идентификатор", "id", idStr, "method", c.Request.Method, "uri", c.FullPath())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return 0, false
+	}
+	return uint(id), true
+}
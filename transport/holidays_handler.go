@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HolidaysHandler struct {
+	logger  *slog.Logger
+	service service.HolidayService
+}
+
+func NewHolidaysHandler(logger *slog.Logger, service service.HolidayService) *HolidaysHandler {
+	return &HolidaysHandler{logger: logger, service: service}
+}
+
+func (h *HolidaysHandler) RegisterRoutes(r *gin.Engine) {
+
+	holidays := r.Group("/holidays")
+	{
+		holidays.GET("/", h.GetInRange)
+		holidays.POST("/", h.Create)
+		holidays.POST("/import", h.Import)
+		holidays.DELETE("/:id", h.Delete)
+	}
+}
+
+func (h *HolidaysHandler) GetInRange(c *gin.Context) {
+	holidays, err := h.service.GetInRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, holidays)
+}
+
+func (h *HolidaysHandler) Create(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	var req models.HolidayCreateReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации CreateHoliday", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holiday, err := h.service.Create(&req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка CreateHoliday", "error", err, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ CreateHoliday", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", holiday.ID)
+	c.JSON(http.StatusCreated, holiday)
+}
+
+func (h *HolidaysHandler) Import(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Warn("файл не передан в ImportHolidays", "reason", err.Error(), "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "нужен .ics файл в поле file"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	imported, err := h.service.Import(file)
+	if err != nil {
+		h.logger.Error("сервисная ошибка ImportHolidays", "error", err, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ ImportHolidays", "method", method, "uri", uri, "status_code", http.StatusOK, "imported", imported)
+	c.JSON(http.StatusOK, gin.H{"imported": imported})
+}
+
+func (h *HolidaysHandler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,197 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewsHandler struct {
+	logger  *slog.Logger
+	service service.ReviewsService
+}
+
+func NewReviewsHandler(logger *slog.Logger, service service.ReviewsService) *ReviewsHandler {
+	return &ReviewsHandler{logger: logger, service: service}
+}
+
+func (h *ReviewsHandler) RegisterRoutes(r *gin.Engine) {
+	r.POST("/appointments/:id/review", h.Create)
+	r.GET("/appointments/:id/review", h.GetByAppointmentID)
+	r.GET("/barbers/:id/reviews", h.GetByBarberID)
+	r.GET("/barbers/:id/rating", h.GetRating)
+	r.POST("/reviews/:id/reply", h.Reply)
+
+	admin := r.Group("/admin/reviews")
+	{
+		admin.GET("/", h.GetForModeration)
+		admin.PATCH("/:id", h.Moderate)
+	}
+}
+
+func (h *ReviewsHandler) Create(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReviewCreateReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации CreateReview", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.service.Create(id, &req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка CreateReview", "error", err, "appointment_id", id, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ CreateReview", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", review.ID)
+	c.JSON(http.StatusCreated, review)
+}
+
+func (h *ReviewsHandler) GetByAppointmentID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	review, err := h.service.GetByAppointmentID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, publicReview(review))
+}
+
+func (h *ReviewsHandler) GetByBarberID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	reviews, err := h.service.GetByBarberID(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res := make([]models.PublicReviewDTO, 0, len(reviews))
+	for i := range reviews {
+		res = append(res, publicReview(&reviews[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *ReviewsHandler) GetRating(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	rating, err := h.service.GetRating(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rating)
+}
+
+func (h *ReviewsHandler) Reply(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReviewReplyReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации ReplyReview", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.service.Reply(id, &req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка ReplyReview", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ ReplyReview", "method", method, "uri", uri, "status_code", http.StatusOK, "entity_id", review.ID)
+	c.JSON(http.StatusOK, review)
+}
+
+func (h *ReviewsHandler) GetForModeration(c *gin.Context) {
+	reviews, err := h.service.GetForModeration(c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+func (h *ReviewsHandler) Moderate(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReviewModerateReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации ModerateReview", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.service.Moderate(id, &req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка ModerateReview", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ ModerateReview", "method", method, "uri", uri, "status_code", http.StatusOK, "entity_id", review.ID, "status", review.Status)
+	c.JSON(http.StatusOK, review)
+}
+
+func (h *ReviewsHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("некорректный идентификатор", "id", idStr, "method", c.Request.Method, "uri", c.FullPath())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// publicReview оставляет в отзыве только то, что можно показать без авторизации:
+// без клиента, записи и причины модерации.
+func publicReview(review *models.Review) models.PublicReviewDTO {
+	return models.PublicReviewDTO{
+		Rating:    review.Rating,
+		Text:      review.Text,
+		CreatedAt: review.CreatedAt,
+		Reply:     review.Reply,
+		RepliedAt: review.RepliedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"barber-backend-api/internal/events"
 	"barber-backend-api/service"
 	"log/slog"
 
@@ -12,6 +13,18 @@ func RegisterRoutes(
 	appointments service.AppointmentsService,
 	barbers service.BarberService,
 	clients service.ClientService,
+	schedules service.BarberScheduleService,
+	timeOffs service.TimeOffService,
+	holidays service.HolidayService,
+	availability service.AvailabilityService,
+	catalog service.CatalogService,
+	menus service.BarberMenuService,
+	waitlist service.WaitlistService,
+	holds service.HoldService,
+	walkIns service.WalkInService,
+	reviews service.ReviewsService,
+	profiles service.BarberProfileService,
+	bus *events.Bus,
 	logger *slog.Logger,
 
 ) {
@@ -19,9 +32,33 @@ func RegisterRoutes(
 	appointmentsHandler := NewAppointmentsHandler(appointments)
 	barbersHandler := NewBarberHandler(logger, barbers)
 	clientsHandler := NewClientsHandler(clients)
+	schedulesHandler := NewBarberScheduleHandler(logger, schedules)
+	timeOffHandler := NewTimeOffHandler(logger, timeOffs)
+	holidaysHandler := NewHolidaysHandler(logger, holidays)
+	availabilityHandler := NewAvailabilityHandler(availability)
+	servicesHandler := NewServicesHandler(catalog)
+	barberMenuHandler := NewBarberMenuHandler(logger, menus)
+	waitlistHandler := NewWaitlistHandler(logger, waitlist)
+	holdsHandler := NewHoldsHandler(logger, holds)
+	walkInHandler := NewWalkInHandler(logger, walkIns)
+	eventsHandler := NewEventsHandler(logger, bus)
+	reviewsHandler := NewReviewsHandler(logger, reviews)
+	barberProfileHandler := NewBarberProfileHandler(logger, profiles)
 
 	// Каждый хендлер регистрирует маршруты в рамках своей ответственности
 	appointmentsHandler.RegisterRoutes(router)
 	barbersHandler.RegisterRoutes(router)
 	clientsHandler.RegisterRoutes(router)
+	schedulesHandler.RegisterRoutes(router)
+	timeOffHandler.RegisterRoutes(router)
+	holidaysHandler.RegisterRoutes(router)
+	availabilityHandler.RegisterRoutes(router)
+	servicesHandler.RegisterRoutes(router)
+	barberMenuHandler.RegisterRoutes(router)
+	waitlistHandler.RegisterRoutes(router)
+	holdsHandler.RegisterRoutes(router)
+	walkInHandler.RegisterRoutes(router)
+	eventsHandler.RegisterRoutes(router)
+	reviewsHandler.RegisterRoutes(router)
+	barberProfileHandler.RegisterRoutes(router)
 }
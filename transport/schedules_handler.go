@@ -0,0 +1,142 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BarberScheduleHandler struct {
+	logger  *slog.Logger
+	service service.BarberScheduleService
+}
+
+func NewBarberScheduleHandler(logger *slog.Logger, service service.BarberScheduleService) *BarberScheduleHandler {
+	return &BarberScheduleHandler{logger: logger, service: service}
+}
+
+func (h *BarberScheduleHandler) RegisterRoutes(r *gin.Engine) {
+
+	schedule := r.Group("/barbers/:id/schedule")
+	{
+		schedule.GET("/", h.GetByBarberID)
+		schedule.POST("/", h.Create)
+		schedule.PUT("/:scheduleID", h.Update)
+		schedule.DELETE("/:scheduleID", h.Delete)
+	}
+}
+
+func (h *BarberScheduleHandler) GetByBarberID(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	schedules, err := h.service.GetByBarberID(barberID)
+	if err != nil {
+		h.logger.Error("сервисная ошибка GetSchedule", "error", err, "barber_id", barberID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+func (h *BarberScheduleHandler) Create(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.BarberScheduleReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации CreateSchedule", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := h.service.Create(barberID, &req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка CreateSchedule", "error", err, "barber_id", barberID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ CreateSchedule", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", schedule.ID)
+	c.JSON(http.StatusCreated, schedule)
+}
+
+func (h *BarberScheduleHandler) Update(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	scheduleID, ok := h.parseID(c, "scheduleID")
+	if !ok {
+		return
+	}
+
+	var req models.BarberScheduleReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации UpdateSchedule", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := h.service.Update(barberID, scheduleID, &req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка UpdateSchedule", "error", err, "id", scheduleID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ UpdateSchedule", "method", method, "uri", uri, "status_code", http.StatusOK, "entity_id", schedule.ID)
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *BarberScheduleHandler) Delete(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	scheduleID, ok := h.parseID(c, "scheduleID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(barberID, scheduleID); err != nil {
+		h.logger.Error("сервисная ошибка DeleteSchedule", "error", err, "id", scheduleID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ DeleteSchedule", "method", method, "uri", uri, "status_code", http.StatusNoContent, "entity_id", scheduleID)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BarberScheduleHandler) parseID(c *gin.Context, param string) (uint, bool) {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("некорректный идентификатор", "param", param, "id", idStr, "method", c.Request.Method, "uri", c.FullPath())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return 0, false
+	}
+	return uint(id), true
+}
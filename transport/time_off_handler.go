@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeOffHandler struct {
+	logger  *slog.Logger
+	service service.TimeOffService
+}
+
+func NewTimeOffHandler(logger *slog.Logger, service service.TimeOffService) *TimeOffHandler {
+	return &TimeOffHandler{logger: logger, service: service}
+}
+
+func (h *TimeOffHandler) RegisterRoutes(r *gin.Engine) {
+
+	timeOff := r.Group("/barbers/:id/time-off")
+	{
+		timeOff.GET("/", h.GetByBarberID)
+		timeOff.POST("/", h.Create)
+		timeOff.POST("/:timeOffID/cancel", h.Cancel)
+	}
+}
+
+func (h *TimeOffHandler) GetByBarberID(c *gin.Context) {
+	barberID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	timeOffs, err := h.service.GetByBarberID(uint(barberID))
+	if err != nil {
+		h.logger.Error("сервисная ошибка GetTimeOff", "error", err, "barber_id", barberID, "method", c.Request.Method, "uri", c.FullPath())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, timeOffs)
+}
+
+func (h *TimeOffHandler) Create(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	var req models.TimeOffCreateReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации CreateTimeOff", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.service.Create(uint(barberID), &req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка CreateTimeOff", "error", err, "barber_id", barberID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ CreateTimeOff", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", res.TimeOff.ID, "conflicts", len(res.Conflicts))
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *TimeOffHandler) Cancel(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	barberID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+	timeOffID, err := strconv.ParseUint(c.Param("timeOffID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return
+	}
+
+	timeOff, err := h.service.Cancel(uint(barberID), uint(timeOffID))
+	if err != nil {
+		h.logger.Error("сервисная ошибка CancelTimeOff", "error", err, "id", timeOffID, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ CancelTimeOff", "method", method, "uri", uri, "status_code", http.StatusOK, "entity_id", timeOff.ID)
+	c.JSON(http.StatusOK, timeOff)
+}
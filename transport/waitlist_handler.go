@@ -0,0 +1,143 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistHandler struct {
+	logger  *slog.Logger
+	service service.WaitlistService
+}
+
+func NewWaitlistHandler(logger *slog.Logger, service service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{logger: logger, service: service}
+}
+
+func (h *WaitlistHandler) RegisterRoutes(r *gin.Engine) {
+
+	waitlist := r.Group("/waitlist")
+	{
+		waitlist.GET("/", h.GetAll)
+		waitlist.GET("/:id", h.GetByID)
+		waitlist.POST("/", h.Join)
+		waitlist.POST("/:id/claim", h.Claim)
+		waitlist.DELETE("/:id", h.Leave)
+	}
+}
+
+func (h *WaitlistHandler) GetAll(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	var barberID uint
+	if raw := c.Query("barber_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор парикмахера"})
+			return
+		}
+		barberID = uint(id)
+	}
+
+	entries, err := h.service.GetAll(barberID, c.Query("date"))
+	if err != nil {
+		h.logger.Error("сервисная ошибка GetWaitlist", "error", err, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *WaitlistHandler) GetByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *WaitlistHandler) Join(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	var req models.WaitlistJoinReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации JoinWaitlist", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.Join(&req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка JoinWaitlist", "error", err, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ JoinWaitlist", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", entry.ID)
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *WaitlistHandler) Claim(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	appointment, err := h.service.Claim(id)
+	if err != nil {
+		h.logger.Error("сервисная ошибка ClaimWaitlist", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ ClaimWaitlist", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", appointment.ID)
+	c.JSON(http.StatusCreated, appointment)
+}
+
+func (h *WaitlistHandler) Leave(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Leave(id); err != nil {
+		h.logger.Error("сервисная ошибка LeaveWaitlist", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ LeaveWaitlist", "method", method, "uri", uri, "status_code", http.StatusNoContent, "entity_id", id)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WaitlistHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("некорректный идентификатор", "id", idStr, "method", c.Request.Method, "uri", c.FullPath())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,162 @@
+package transport
+
+import (
+	"barber-backend-api/internal/models"
+	"barber-backend-api/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WalkInHandler struct {
+	logger  *slog.Logger
+	service service.WalkInService
+}
+
+func NewWalkInHandler(logger *slog.Logger, service service.WalkInService) *WalkInHandler {
+	return &WalkInHandler{logger: logger, service: service}
+}
+
+func (h *WalkInHandler) RegisterRoutes(r *gin.Engine) {
+
+	queue := r.Group("/queue")
+	{
+		queue.GET("/", h.GetQueue)
+		queue.GET("/:id", h.GetByID)
+		queue.POST("/", h.Join)
+		queue.POST("/call", h.CallNext)
+		queue.PATCH("/:id/position", h.Move)
+		queue.DELETE("/:id", h.Leave)
+	}
+}
+
+func (h *WalkInHandler) GetQueue(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	entries, err := h.service.GetQueue()
+	if err != nil {
+		h.logger.Error("сервисная ошибка GetQueue", "error", err, "method", method, "uri", uri)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *WalkInHandler) GetByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *WalkInHandler) Join(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	var req models.WalkInJoinReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации JoinQueue", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.Join(&req)
+	if err != nil {
+		h.logger.Error("сервисная ошибка JoinQueue", "error", err, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ JoinQueue", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", entry.ID)
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *WalkInHandler) CallNext(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	var req models.WalkInCallReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации CallNext", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	appointment, err := h.service.CallNext(req.BarberID)
+	if err != nil {
+		h.logger.Error("сервисная ошибка CallNext", "error", err, "barber_id", req.BarberID, "method", method, "uri", uri)
+		c.JSON(appointmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ CallNext", "method", method, "uri", uri, "status_code", http.StatusCreated, "entity_id", appointment.ID)
+	c.JSON(http.StatusCreated, appointment)
+}
+
+func (h *WalkInHandler) Move(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req models.WalkInMoveReqDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("ошибка валидации MoveInQueue", "reason", err.Error(), "body_valid", false, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.service.Move(id, req.Position)
+	if err != nil {
+		h.logger.Error("сервисная ошибка MoveInQueue", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *WalkInHandler) Leave(c *gin.Context) {
+	method := c.Request.Method
+	uri := c.FullPath()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Leave(id); err != nil {
+		h.logger.Error("сервисная ошибка LeaveQueue", "error", err, "id", id, "method", method, "uri", uri)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info("успешный ответ LeaveQueue", "method", method, "uri", uri, "status_code", http.StatusNoContent, "entity_id", id)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WalkInHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("некорректный идентификатор", "id", idStr, "method", c.Request.Method, "uri", c.FullPath())
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный идентификатор"})
+		return 0, false
+	}
+	return uint(id), true
+}